@@ -0,0 +1,135 @@
+package vector
+
+import (
+	"sort"
+	"sync"
+)
+
+const (
+	// Default size of samples window.
+	adaptiveWindow = 64
+	// Estimate recalculates each N observations.
+	adaptiveRecalc = 8
+)
+
+// Adaptive tracks recent usage of vectors (nodes count, per-depth index rows lengths and buffer length) and pre-sizes
+// vectors on reset using the given percentile of it.
+//
+// Estimator is thread-safe and may be shared between vectors (see Pool.Adaptive).
+type Adaptive struct {
+	percentile float64
+
+	mux sync.Mutex
+	// Ring of samples.
+	ring []sample
+	pos  int
+	n    int
+	// Current estimate and scratch space.
+	est sample
+	tmp []int
+}
+
+type sample struct {
+	nodes, buf int
+	rows       []int
+}
+
+// NewAdaptive makes new estimator with given size of samples window and percentile in range (0, 1].
+func NewAdaptive(window int, percentile float64) *Adaptive {
+	if window <= 0 {
+		window = adaptiveWindow
+	}
+	if percentile <= 0 || percentile > 1 {
+		percentile = 1
+	}
+	return &Adaptive{
+		percentile: percentile,
+		ring:       make([]sample, window),
+	}
+}
+
+// SetAdaptive enables adaptive mode using estimator a. Nil disables it.
+//
+// In adaptive mode vector records its usage on each reset and pre-sizes nodes array, index rows and buffer to avoid
+// growth reallocations in steady state.
+func (vec *Vector) SetAdaptive(a *Adaptive) {
+	vec.adp = a
+}
+
+// Record usage of the vector.
+func (a *Adaptive) observe(vec *Vector) {
+	if vec.nodeL == 0 {
+		return
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	s := &a.ring[a.pos]
+	s.nodes, s.buf = vec.nodeL, len(vec.buf)
+	s.rows = s.rows[:0]
+	for i := 0; i < len(vec.Index.tree); i++ {
+		s.rows = append(s.rows, vec.Index.tree[i].len)
+	}
+	a.pos = (a.pos + 1) % len(a.ring)
+	a.n++
+	if a.n == 1 || a.n%adaptiveRecalc == 0 {
+		a.estimate()
+	}
+}
+
+// Calculate estimate using samples in the ring.
+func (a *Adaptive) estimate() {
+	n := a.n
+	if n > len(a.ring) {
+		n = len(a.ring)
+	}
+	var depth int
+	for i := 0; i < n; i++ {
+		if l := len(a.ring[i].rows); l > depth {
+			depth = l
+		}
+	}
+	a.est.nodes = a.pick(n, func(s *sample) int { return s.nodes })
+	a.est.buf = a.pick(n, func(s *sample) int { return s.buf })
+	a.est.rows = a.est.rows[:0]
+	for d := 0; d < depth; d++ {
+		a.est.rows = append(a.est.rows, a.pick(n, func(s *sample) int {
+			if d < len(s.rows) {
+				return s.rows[d]
+			}
+			return 0
+		}))
+	}
+}
+
+// Get percentile of values taken from first n samples.
+func (a *Adaptive) pick(n int, fn func(s *sample) int) int {
+	a.tmp = a.tmp[:0]
+	for i := 0; i < n; i++ {
+		a.tmp = append(a.tmp, fn(&a.ring[i]))
+	}
+	sort.Ints(a.tmp)
+	return a.tmp[int(float64(n-1)*a.percentile)]
+}
+
+// Pre-size vector's arrays according current estimate.
+func (a *Adaptive) presize(vec *Vector) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.n == 0 {
+		return
+	}
+	if a.est.nodes > cap(vec.nodes) {
+		vec.Prealloc(uint(a.est.nodes))
+	}
+	for len(vec.Index.tree) < len(a.est.rows) {
+		vec.Index.tree = append(vec.Index.tree, branch{})
+	}
+	for i := 0; i < len(a.est.rows); i++ {
+		if b := &vec.Index.tree[i]; a.est.rows[i] > cap(b.buf) {
+			b.buf = make([]int, 0, a.est.rows[i])
+		}
+	}
+	if a.est.buf > cap(vec.buf) {
+		vec.buf = make([]byte, 0, a.est.buf)
+	}
+}
@@ -0,0 +1,28 @@
+package vector
+
+import "testing"
+
+func TestAdaptive(t *testing.T) {
+	a := NewAdaptive(4, 1)
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.SetAdaptive(nil)
+	defer vec.Reset()
+	vec.Reset()
+	vec.SetAdaptive(a)
+	_ = vec.SetSrc([]byte(`{"a":{"b":"foo"},"c":[1,2]}`), false)
+	testEmit(vec)
+	vec.Bufferize(make([]byte, 100))
+	vec.Reset()
+
+	cpy := testPool.Get().(*Vector)
+	defer testPool.Put(cpy)
+	defer cpy.SetAdaptive(nil)
+	defer cpy.Reset()
+	cpy.SetAdaptive(a)
+	cpy.Reset()
+	s := cpy.Stats()
+	if s.NodesCap < 6*nodeSize || s.IndexCap < 6*intSize || s.BufCap < 100 {
+		t.Errorf("vector must be pre-sized: %+v", s)
+	}
+}
@@ -19,14 +19,30 @@ var lowerTableASCII = [256]byte{
 	0xf0, 0xf1, 0xf2, 0xf3, 0xf4, 0xf5, 0xf6, 0xf7, 0xf8, 0xf9, 0xfa, 0xfb, 0xfc, 0xfd, 0xfe, 0xff,
 }
 
-func toLowerASCII(b []byte) []byte {
-	n := len(b)
-	if n == 0 {
-		return b
+// Trim leading and trailing ASCII whitespaces.
+func trimSpaceASCII(b []byte) []byte {
+	for len(b) > 0 && isSpaceASCII(b[0]) {
+		b = b[1:]
 	}
-	_ = b[n-1]
-	for i, c := range b {
-		b[i] = lowerTableASCII[c]
+	for len(b) > 0 && isSpaceASCII(b[len(b)-1]) {
+		b = b[:len(b)-1]
 	}
 	return b
 }
+
+func isSpaceASCII(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// Case-insensitive comparison of b and s. Doesn't modify b.
+func equalFoldASCII(b []byte, s string) bool {
+	if len(b) != len(s) {
+		return false
+	}
+	for i := 0; i < len(b); i++ {
+		if lowerTableASCII[b[i]] != lowerTableASCII[s[i]] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,380 @@
+package vector
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/koykov/byteconv"
+)
+
+// NumberKind represents kind of numeric value (see Node.NumberKind).
+type NumberKind uint8
+
+const (
+	// NumberInvalid means node isn't a number or number is malformed.
+	NumberInvalid NumberKind = iota
+	// NumberInteger means integer that fits int64 or uint64.
+	NumberInteger
+	// NumberFloat means float number that float64 represents without precision loss.
+	NumberFloat
+	// NumberOverflow means number that can't be represented by 64-bit types without loss (see BigInt, BigFloat and
+	// Decimal).
+	NumberOverflow
+)
+
+// Decimal represents lossless decimal number (-1)^Neg * (Hi*2^64 + Lo) * 10^Exp.
+//
+// Mantissa may contain up to 38 decimal digits.
+type Decimal struct {
+	Hi, Lo uint64
+	Exp    int32
+	Neg    bool
+}
+
+// AppendString appends string representation of d to dst in form "[-]<mantissa>[e<exp>]".
+func (d Decimal) AppendString(dst []byte) []byte {
+	var (
+		a      [40]byte
+		i      = len(a)
+		hi, lo = d.Hi, d.Lo
+		r      uint64
+	)
+	for {
+		hi, r = bits.Div64(0, hi, 10)
+		lo, r = bits.Div64(r, lo, 10)
+		i--
+		a[i] = byte('0' + r)
+		if hi == 0 && lo == 0 {
+			break
+		}
+	}
+	if d.Neg {
+		dst = append(dst, '-')
+	}
+	dst = append(dst, a[i:]...)
+	if d.Exp != 0 {
+		dst = append(dst, 'e')
+		dst = strconv.AppendInt(dst, int64(d.Exp), 10)
+	}
+	return dst
+}
+
+// String returns string representation of d.
+func (d Decimal) String() string {
+	return string(d.AppendString(nil))
+}
+
+// NumberKind reports kind of the numeric value.
+func (n *Node) NumberKind() NumberKind {
+	if n.typ != TypeNumber {
+		return NumberInvalid
+	}
+	b, o := n.val.Bytes(), n.numOpts()
+	switch u, neg, err := scanUint(b, o); err {
+	case nil:
+		if neg && u > 1<<63 {
+			// Magnitude fits uint64, but value doesn't fit int64.
+			return NumberOverflow
+		}
+		return NumberInteger
+	case ErrOverflow:
+		return NumberOverflow
+	}
+	f, err := parseFloat(b, 64, o)
+	switch {
+	case err == ErrOverflow:
+		return NumberOverflow
+	case err != nil:
+		return NumberInvalid
+	case math.IsInf(f, 0) || math.IsNaN(f):
+		return NumberFloat
+	}
+	// Shortest representation of f contains less digits than source, so precision was lost.
+	var a [32]byte
+	if sigDigits(b) > sigDigits(strconv.AppendFloat(a[:0], f, 'e', -1, 64)) {
+		return NumberOverflow
+	}
+	return NumberFloat
+}
+
+// Count significant digits of decimal number.
+func sigDigits(b []byte) (n int) {
+	var zeros int
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c|0x20 == 'e' {
+			break
+		}
+		if !isDigit(c) || (c == '0' && n == 0) {
+			continue
+		}
+		if c == '0' {
+			zeros++
+		} else {
+			zeros = 0
+		}
+		n++
+	}
+	return n - zeros
+}
+
+// Maximum decimal exponent of floats read as arbitrary-precision integer (see BigInt).
+const bigMaxExp = 1 << 14
+
+// BigInt returns value as arbitrary-precision integer.
+//
+// Exactly integral floats (eg 1e30) are also allowed. Returns ErrOverflow if exponent of the float exceeds 16384.
+func (n *Node) BigInt() (*big.Int, error) {
+	if n.typ != TypeNumber {
+		return nil, ErrIncompatType
+	}
+	b, o := n.val.Bytes(), n.numOpts()
+	u, neg, err := scanUint(b, o)
+	switch err {
+	case nil:
+		x := new(big.Int).SetUint64(u)
+		if neg {
+			x.Neg(x)
+		}
+		return x, nil
+	case ErrOverflow:
+		b, base := bigDigits(b, o)
+		x, ok := new(big.Int).SetString(byteconv.B2S(b), base)
+		if !ok {
+			return nil, ErrBadNumber
+		}
+		return x, nil
+	}
+	if _, err = parseFloat(b, 64, o); err != nil && err != ErrOverflow {
+		return nil, err
+	}
+	b, _ = bigDigits(b, o)
+	if s := trimSign(b); len(s) > 0 && (s[0]|0x20 == 'i' || s[0]|0x20 == 'n') {
+		// Inf and NaN aren't integers.
+		return nil, ErrIncompatType
+	}
+	// Check exponent before math/big builds huge numbers.
+	digits, exp := bigScale(b)
+	switch {
+	case digits == 0:
+		return new(big.Int), nil
+	case exp > bigMaxExp:
+		return nil, ErrOverflow
+	case exp < -digits:
+		return nil, ErrIncompatType
+	}
+	r, ok := new(big.Rat).SetString(byteconv.B2S(b))
+	if !ok {
+		return nil, ErrBadNumber
+	}
+	if !r.IsInt() {
+		return nil, ErrIncompatType
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// Count significant digits of syntactically valid decimal float b and get decimal exponent of its last digit.
+//
+// Exponent saturates, thus huge values don't overflow int.
+func bigScale(b []byte) (digits, exp int) {
+	s := trimSign(b)
+	var point bool
+	i := 0
+	for ; i < len(s) && s[i]|0x20 != 'e'; i++ {
+		c := s[i]
+		if c == '.' {
+			point = true
+			continue
+		}
+		if point {
+			exp--
+		}
+		if c != '0' || digits > 0 {
+			digits++
+		}
+	}
+	if i++; i < len(s) {
+		neg := s[i] == '-'
+		if s[i] == '+' || s[i] == '-' {
+			i++
+		}
+		var e int
+		for ; i < len(s) && e < 1<<30; i++ {
+			e = e*10 + int(s[i]-'0')
+		}
+		if neg {
+			e = -e
+		}
+		exp += e
+	}
+	return
+}
+
+// BigFloat returns value as arbitrary-precision float with given precision.
+//
+// Zero prec means 64 bits (see big.Float.Parse).
+func (n *Node) BigFloat(prec uint) (*big.Float, error) {
+	if n.typ != TypeNumber {
+		return nil, ErrIncompatType
+	}
+	b, o := n.val.Bytes(), n.numOpts()
+	if _, err := parseFloat(b, 64, o); err != nil && err != ErrOverflow {
+		return nil, err
+	}
+	b, base := bigDigits(b, o)
+	if base != 10 {
+		x, ok := new(big.Int).SetString(byteconv.B2S(b), base)
+		if !ok {
+			return nil, ErrBadNumber
+		}
+		return new(big.Float).SetPrec(prec).SetInt(x), nil
+	}
+	if s := trimSign(b); len(s) > 0 && (s[0]|0x20 == 'i' || s[0]|0x20 == 'n') {
+		if s[0]|0x20 == 'n' {
+			// NaN isn't representable by big.Float.
+			return nil, ErrIncompatType
+		}
+		return new(big.Float).SetPrec(prec).SetInf(len(s) < len(b) && b[0] == '-'), nil
+	}
+	f, _, err := new(big.Float).SetPrec(prec).Parse(byteconv.B2S(b), 10)
+	if err != nil {
+		return nil, ErrBadNumber
+	}
+	return f, nil
+}
+
+// Decimal returns value as lossless decimal without allocations.
+//
+// Returns ErrOverflow if mantissa exceeds 38 digits.
+func (n *Node) Decimal() (Decimal, error) {
+	if n.typ != TypeNumber {
+		return Decimal{}, ErrIncompatType
+	}
+	return parseDecimal(n.val.Bytes(), n.numOpts())
+}
+
+// Parse b as lossless decimal.
+func parseDecimal(b []byte, o numOpts) (d Decimal, err error) {
+	b = trimSpaceASCII(b)
+	s := trimSign(b)
+	d.Neg = len(s) < len(b) && b[0] == '-'
+	if len(s) == 0 {
+		return d, ErrBadNumber
+	}
+	if o&numPrefix != 0 && len(s) > 2 && s[0] == '0' && (s[1]|0x20 == 'x' || s[1]|0x20 == 'o' || s[1]|0x20 == 'b') {
+		d.Lo, _, err = scanUint(b, o)
+		if err == errNotInt {
+			err = ErrBadNumber
+		}
+		return
+	}
+	var (
+		exp           int64
+		digits, point bool
+		ok            bool
+		i             int
+	)
+loop:
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+			if d.Hi, d.Lo, ok = mul10add(d.Hi, d.Lo, uint64(c-'0')); !ok {
+				return d, ErrOverflow
+			}
+			if point {
+				exp--
+			}
+			continue
+		case c == '.' && !point:
+			point = true
+			continue
+		case c == '_' && o&numUnderscore != 0 && i > 0 && i < len(s)-1 && isDigit(s[i-1]) && isDigit(s[i+1]):
+			continue
+		}
+		break loop
+	}
+	if !digits {
+		return d, ErrBadNumber
+	}
+	if i < len(s) {
+		if s[i]|0x20 != 'e' {
+			return d, ErrBadNumber
+		}
+		e, err := strconv.ParseInt(byteconv.B2S(s[i+1:]), 10, 32)
+		if err != nil {
+			if isRangeErr(err) {
+				return d, ErrOverflow
+			}
+			return d, ErrBadNumber
+		}
+		exp += e
+	}
+	if exp < -1<<31 || exp > 1<<31-1 {
+		return d, ErrOverflow
+	}
+	d.Exp = int32(exp)
+	return d, nil
+}
+
+// Calculate (hi, lo)*10+d. Returns false on overflow.
+func mul10add(hi, lo, d uint64) (uint64, uint64, bool) {
+	hh, hl := bits.Mul64(hi, 10)
+	lh, ll := bits.Mul64(lo, 10)
+	h, c := bits.Add64(hl, lh, 0)
+	if hh != 0 || c != 0 {
+		return 0, 0, false
+	}
+	ll, c = bits.Add64(ll, d, 0)
+	h, c = bits.Add64(h, 0, c)
+	return h, ll, c == 0
+}
+
+// Prepare syntactically valid number for math/big parsing.
+//
+// Removes underscores and base prefix, returns base of the number.
+func bigDigits(b []byte, o numOpts) ([]byte, int) {
+	b = trimSpaceASCII(b)
+	base := 10
+	if o&numPrefix != 0 {
+		s := trimSign(b)
+		if len(s) > 2 && s[0] == '0' {
+			switch s[1] | 0x20 {
+			case 'x':
+				base = 16
+			case 'o':
+				base = 8
+			case 'b':
+				base = 2
+			}
+		}
+	}
+	if base == 10 && (o&numUnderscore == 0 || bytes.IndexByte(b, '_') < 0) {
+		return b, base
+	}
+	r := make([]byte, 0, len(b))
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		r, b = append(r, b[0]), b[1:]
+	}
+	if base != 10 {
+		b = b[2:]
+	}
+	for i := 0; i < len(b); i++ {
+		if b[i] != '_' {
+			r = append(r, b[i])
+		}
+	}
+	return r, base
+}
+
+// Remove sign of the number.
+func trimSign(b []byte) []byte {
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		return b[1:]
+	}
+	return b
+}
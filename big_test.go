@@ -0,0 +1,67 @@
+package vector
+
+import "testing"
+
+func TestBig(t *testing.T) {
+	const src = `[12345678901234567890.123456789,-98765432109876543210,1.5,42,1e30,1e400,-18446744073709551615,1e999999,1.20e1,0.0e-999999,Inf]`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitArrayStart()
+	for _, s := range [][2]int{{1, 30}, {32, 21}, {54, 3}, {58, 2}, {61, 4}, {66, 5}, {72, 21}, {94, 8}, {103, 6}, {110, 11}, {122, 3}} {
+		_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), s[0], s[1]))
+	}
+	_ = vec.EmitEnd()
+
+	kinds := []NumberKind{NumberOverflow, NumberOverflow, NumberFloat, NumberInteger, NumberFloat, NumberOverflow, NumberOverflow, NumberOverflow}
+	for i, k := range kinds {
+		if nk := vec.Root().At(i).NumberKind(); nk != k {
+			t.Errorf("kind #%d mismatch: need %d, got %d", i, k, nk)
+		}
+	}
+
+	d, err := vec.DotDecimal("0")
+	if err != nil || d.String() != "12345678901234567890123456789e-9" {
+		t.Errorf("decimal mismatch: %s, %v", d, err)
+	}
+	if d, _ = vec.Root().DotDecimal("1"); !d.Neg || d.Exp != 0 || d.String() != "-98765432109876543210" {
+		t.Errorf("decimal mismatch: %s", d)
+	}
+
+	if x, err := vec.DotBigInt("1"); err != nil || x.String() != "-98765432109876543210" {
+		t.Errorf("big int mismatch: %v, %v", x, err)
+	}
+	if x, err := vec.DotBigInt("4"); err != nil || x.String() != "1000000000000000000000000000000" {
+		t.Errorf("big int mismatch: %v, %v", x, err)
+	}
+	if x, err := vec.DotBigInt("6"); err != nil || x.String() != "-18446744073709551615" {
+		t.Errorf("big int mismatch: %v, %v", x, err)
+	}
+	if _, err := vec.DotBigInt("7"); err != ErrOverflow {
+		t.Errorf("huge exponent must overflow, got %v", err)
+	}
+	if _, err := vec.DotBigInt("2"); err != ErrIncompatType {
+		t.Errorf("fractional big int must fail, got %v", err)
+	}
+	if x, err := vec.DotBigInt("8"); err != nil || x.String() != "12" {
+		t.Errorf("big int mismatch: %v, %v", x, err)
+	}
+	if x, err := vec.DotBigInt("9"); err != nil || x.Sign() != 0 {
+		t.Errorf("big int mismatch: %v, %v", x, err)
+	}
+	vec.SetBit(FlagNumInfNaN, true)
+	if _, err := vec.DotBigInt("10"); err != ErrIncompatType {
+		t.Errorf("infinity must fail, got %v", err)
+	}
+
+	f, err := vec.DotBigFloat(128, "0")
+	if err != nil || f.Text('f', 9) != "12345678901234567890.123456789" {
+		t.Errorf("big float mismatch: %v, %v", f, err)
+	}
+	if f, err = vec.Root().DotBigFloat(0, "5"); err != nil || f.Text('g', 3) != "1e+400" {
+		t.Errorf("big float mismatch: %v, %v", f, err)
+	}
+}
@@ -0,0 +1,156 @@
+package vector
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/koykov/bytealg"
+)
+
+// BinaryEncoding represents encoding of binary data embedded to string values (see Node.Binary).
+type BinaryEncoding uint8
+
+const (
+	// BinaryBase64 detects standard or URL base64 alphabet and padding automatically.
+	BinaryBase64 BinaryEncoding = iota
+	// BinaryHex means hexadecimal encoding.
+	BinaryHex
+	// BinaryBase32 means standard base32 encoding.
+	BinaryBase32
+	// BinaryBase32Hex means base32 encoding with extended hex alphabet.
+	BinaryBase32Hex
+)
+
+// SetBinaryEncoding sets encoding of binary values (see Node.Binary). Encoding keeps after Reset.
+func (vec *Vector) SetBinaryEncoding(enc BinaryEncoding) {
+	vec.benc = enc
+}
+
+// BinaryEncoding returns encoding of binary values.
+func (vec *Vector) BinaryEncoding() BinaryEncoding {
+	return vec.benc
+}
+
+// Binary decodes value to the vector's read cache using vector's binary encoding and returns decoded bytes.
+//
+// Result is valid until vector reset.
+func (n *Node) Binary() ([]byte, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return nil, ErrInternal
+	}
+	// Getting of value may cache it in the read cache, thus offset takes after that.
+	src, err := n.binarySrc()
+	if err != nil {
+		return nil, err
+	}
+	off := len(vec.bufRC)
+	switch vec.benc {
+	case BinaryHex:
+		vec.bufRC, err = appendHex(vec.bufRC, src)
+	case BinaryBase32:
+		vec.bufRC, err = appendBase32(vec.bufRC, src, base32.StdEncoding)
+	case BinaryBase32Hex:
+		vec.bufRC, err = appendBase32(vec.bufRC, src, base32.HexEncoding)
+	default:
+		vec.bufRC, err = appendBase64(vec.bufRC, src, nil)
+	}
+	if err != nil {
+		vec.bufRC = vec.bufRC[:off]
+		return nil, err
+	}
+	return vec.bufRC[off:], nil
+}
+
+// AppendBase64 decodes base64 value using enc and appends result to dst.
+//
+// Nil enc means detection of standard/URL alphabet and padding by value.
+func (n *Node) AppendBase64(dst []byte, enc *base64.Encoding) ([]byte, error) {
+	src, err := n.binarySrc()
+	if err != nil {
+		return dst, err
+	}
+	return appendBase64(dst, src, enc)
+}
+
+// AppendHex decodes hex value and appends result to dst.
+func (n *Node) AppendHex(dst []byte) ([]byte, error) {
+	src, err := n.binarySrc()
+	if err != nil {
+		return dst, err
+	}
+	return appendHex(dst, src)
+}
+
+// AppendBase32 decodes base32 value using enc and appends result to dst.
+//
+// Nil enc means base32.StdEncoding.
+func (n *Node) AppendBase32(dst []byte, enc *base32.Encoding) ([]byte, error) {
+	src, err := n.binarySrc()
+	if err != nil {
+		return dst, err
+	}
+	return appendBase32(dst, src, enc)
+}
+
+// Decode base64 src and append result to dst.
+//
+// Decoded bytes are written after len(dst), thus src must not overlap that space.
+func appendBase64(dst, src []byte, enc *base64.Encoding) ([]byte, error) {
+	if enc == nil {
+		enc = detectBase64(src)
+	}
+	off := len(dst)
+	dst = bytealg.GrowDelta(dst, enc.DecodedLen(len(src)))
+	w, err := enc.Decode(dst[off:], src)
+	return dst[:off+w], err
+}
+
+// Decode hex src and append result to dst.
+func appendHex(dst, src []byte) ([]byte, error) {
+	off := len(dst)
+	dst = bytealg.GrowDelta(dst, hex.DecodedLen(len(src)))
+	w, err := hex.Decode(dst[off:], src)
+	return dst[:off+w], err
+}
+
+// Decode base32 src and append result to dst.
+func appendBase32(dst, src []byte, enc *base32.Encoding) ([]byte, error) {
+	if enc == nil {
+		enc = base32.StdEncoding
+	}
+	off := len(dst)
+	dst = bytealg.GrowDelta(dst, enc.DecodedLen(len(src)))
+	w, err := enc.Decode(dst[off:], src)
+	return dst[:off+w], err
+}
+
+// Get encoded binary data.
+func (n *Node) binarySrc() ([]byte, error) {
+	if n.typ != TypeString && n.typ != TypeAttribute {
+		return nil, ErrIncompatType
+	}
+	return trimSpaceASCII(n.val.Bytes()), nil
+}
+
+// Choose base64 encoding by alphabet and padding of src.
+func detectBase64(src []byte) *base64.Encoding {
+	var url bool
+	for i := 0; i < len(src); i++ {
+		if src[i] == '-' || src[i] == '_' {
+			url = true
+			break
+		}
+	}
+	padded := len(src)%4 == 0
+	switch {
+	case url && padded:
+		return base64.URLEncoding
+	case url:
+		return base64.RawURLEncoding
+	case padded:
+		return base64.StdEncoding
+	}
+	return base64.RawStdEncoding
+}
@@ -0,0 +1,87 @@
+package vector
+
+import (
+	"encoding/base32"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	const src = `["Zm9vYmFy","Zm9vYg","_-8","666f6f","MZXW6YTBOI======","Zm9vYmFyYmF6$"]`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.SetBinaryEncoding(BinaryBase64)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitArrayStart()
+	for _, s := range [][2]int{{2, 8}, {13, 6}, {22, 3}, {28, 6}, {37, 16}, {56, 13}} {
+		_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), s[0], s[1]))
+	}
+	_ = vec.EmitEnd()
+	root := vec.Root()
+
+	var buf []byte
+	var err error
+	for i, need := range []string{"foobar", "foob", "\xff\xef"} {
+		if buf, err = root.At(i).AppendBase64(buf[:0], nil); err != nil || string(buf) != need {
+			t.Errorf("base64 #%d mismatch: need %q, got %q/%v", i, need, buf, err)
+		}
+	}
+	if buf, err = root.At(3).AppendHex(buf[:0]); err != nil || string(buf) != "foo" {
+		t.Errorf("hex mismatch: %q/%v", buf, err)
+	}
+	if buf, err = root.At(4).AppendBase32(buf[:0], base32.StdEncoding); err != nil || string(buf) != "foobar" {
+		t.Errorf("base32 mismatch: %q/%v", buf, err)
+	}
+	if _, err = root.AppendHex(nil); err != ErrIncompatType {
+		t.Errorf("array must fail, got %v", err)
+	}
+
+	if b, err := root.At(0).Binary(); err != nil || string(b) != "foobar" {
+		t.Errorf("binary mismatch: %q/%v", b, err)
+	}
+	vec.SetBinaryEncoding(BinaryHex)
+	if b, err := root.At(3).Binary(); err != nil || string(b) != "foo" {
+		t.Errorf("binary mismatch: %q/%v", b, err)
+	}
+	if _, err := root.At(0).Binary(); err == nil {
+		t.Error("invalid hex must fail")
+	}
+
+	vec.SetBinaryEncoding(BinaryBase64)
+	// Tail is corrupted, but leading quantums decode successfully.
+	rcL := len(vec.bufRC)
+	if _, err := root.At(5).Binary(); err == nil {
+		t.Error("corrupted base64 must fail")
+	}
+	if len(vec.bufRC) != rcL {
+		t.Errorf("failed decode must not grow read cache: need %d, got %d", rcL, len(vec.bufRC))
+	}
+	if a := testing.AllocsPerRun(100, func() { _, _ = root.At(0).Binary() }); a > 0 {
+		t.Errorf("binary must not allocate, got %f", a)
+	}
+
+	t.Run("copy unescape", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+
+		vec.SetHelper(testUnescapeHelper{copy: true})
+		_ = vec.SetSrc([]byte(`"Zm9vYmFy"`), false)
+		node, _ := vec.AcquireNodeWithType(0, TypeString)
+		node.Value().Init(vec.Src(), 1, 8)
+		for i := 0; i < 2; i++ {
+			if b, err := node.Binary(); err != nil || string(b) != "foobar" {
+				t.Errorf("binary #%d mismatch: %q/%v", i, b, err)
+			}
+		}
+		if s := node.String(); s != "Zm9vYmFy" {
+			t.Errorf("cached value must stay untouched, got %q", s)
+		}
+		if vec.BufLen() != 0 {
+			t.Error("binary must not grow the buffer")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package vector
+
+// SetBoolVocab sets extra truthy and falsy words, eg yes/no, y/n, 1/0, enabled/disabled.
+//
+// Words match case-insensitively without source modification. Unlike true/false words, extra words match values of
+// string, number and attribute nodes as well. Vocabulary keeps after Reset.
+func (vec *Vector) SetBoolVocab(truthy, falsy []string) {
+	vec.btrue = append(vec.btrue[:0], truthy...)
+	vec.bfalse = append(vec.bfalse[:0], falsy...)
+}
+
+// BoolVocab returns extra truthy and falsy words.
+func (vec *Vector) BoolVocab() (truthy, falsy []string) {
+	return vec.btrue, vec.bfalse
+}
+
+// Bool returns value as boolean.
+//
+// Any value that isn't a bool (see BoolStrict) considers as false.
+func (n *Node) Bool() bool {
+	b, _ := n.BoolStrict()
+	return b
+}
+
+// BoolStrict returns value as boolean or ErrIncompatType if value isn't a bool.
+//
+// Bool nodes match words true/false (and on/off if FlagExtraBool enabled), other scalar nodes match vector's
+// vocabulary (see SetBoolVocab).
+func (n *Node) BoolStrict() (bool, error) {
+	switch n.typ {
+	case TypeBool, TypeString, TypeNumber, TypeAttribute:
+	default:
+		return false, ErrIncompatType
+	}
+	raw := trimSpaceASCII(n.val.RawBytes())
+	vec := n.indirectVector()
+	if n.typ == TypeBool {
+		switch {
+		case equalFoldASCII(raw, "true"):
+			return true, nil
+		case equalFoldASCII(raw, "false"):
+			return false, nil
+		}
+		if n.val.bits.CheckBit(FlagExtraBool) || (vec != nil && vec.CheckBit(FlagExtraBool)) {
+			switch {
+			case equalFoldASCII(raw, "on"):
+				return true, nil
+			case equalFoldASCII(raw, "off"):
+				return false, nil
+			}
+		}
+	}
+	if vec == nil {
+		return false, ErrIncompatType
+	}
+	if n.typ == TypeString || n.typ == TypeAttribute {
+		raw = trimSpaceASCII(n.val.Bytes())
+	}
+	for i := 0; i < len(vec.btrue); i++ {
+		if equalFoldASCII(raw, vec.btrue[i]) {
+			return true, nil
+		}
+	}
+	for i := 0; i < len(vec.bfalse); i++ {
+		if equalFoldASCII(raw, vec.bfalse[i]) {
+			return false, nil
+		}
+	}
+	return false, ErrIncompatType
+}
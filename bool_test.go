@@ -0,0 +1,47 @@
+package vector
+
+import "testing"
+
+func TestBool(t *testing.T) {
+	const src = `[TRUE,false,On,"Yes","n",1,"maybe"]`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.SetBoolVocab(nil, nil)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitArrayStart()
+	_ = vec.EmitScalar(TypeBool, p.Init(vec.Src(), 1, 4))
+	_ = vec.EmitScalar(TypeBool, p.Init(vec.Src(), 6, 5))
+	_ = vec.EmitScalar(TypeBool, p.Init(vec.Src(), 12, 2))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 16, 3))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 22, 1))
+	_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), 25, 1))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 28, 5))
+	_ = vec.EmitEnd()
+
+	check := func(stage string, need []int8) {
+		for i, n := range need {
+			b, err := vec.Root().At(i).BoolStrict()
+			switch {
+			case n < 0 && err != ErrIncompatType:
+				t.Errorf("%s #%d: must fail, got %v/%v", stage, i, b, err)
+			case n >= 0 && (err != nil || b != (n == 1)):
+				t.Errorf("%s #%d: need %d, got %v/%v", stage, i, n, b, err)
+			}
+		}
+	}
+	check("default", []int8{1, 0, -1, -1, -1, -1, -1})
+	vec.SetBit(FlagExtraBool, true)
+	check("extra", []int8{1, 0, 1, -1, -1, -1, -1})
+	vec.SetBoolVocab([]string{"yes", "y", "1"}, []string{"no", "n", "0"})
+	check("vocab", []int8{1, 0, 1, 1, 0, 1, -1})
+
+	if !vec.DotBool("3") || vec.DotBool("6") {
+		t.Error("bool getter mismatch")
+	}
+	if string(vec.Src()) != src {
+		t.Error("source must stay untouched")
+	}
+}
@@ -3,28 +3,20 @@ package vector
 import (
 	"unsafe"
 
-	"github.com/koykov/bitset"
 	"github.com/koykov/byteconv"
 	"github.com/koykov/entry"
 	"github.com/koykov/indirect"
 )
 
-const byteptrSize = 32
-
-type Byteptr struct {
-	addr, vptr uintptr
-
-	bits bitset.Bitset32
-
-	offset, len, cap uint32
-}
-
 func (p *Byteptr) TakeAddr(s []byte) *Byteptr {
 	if s == nil {
 		return p
 	}
 	h := (*byteconv.SliceHeader)(unsafe.Pointer(&s))
-	p.addr, p.cap = h.Data, uint32(h.Cap)
+	p.bits.SetBit(bitBuffered, false)
+	p.bits.SetBit(bitIndirected, false)
+	p.setBaseAddr(h.Data)
+	p.setCap(clampSpan(h.Cap))
 	return p
 }
 
@@ -33,7 +25,10 @@ func (p *Byteptr) TakeStringAddr(s string) *Byteptr {
 		return p
 	}
 	h := (*byteconv.StringHeader)(unsafe.Pointer(&s))
-	p.addr, p.cap = h.Data, uint32(h.Len)
+	p.bits.SetBit(bitBuffered, false)
+	p.bits.SetBit(bitIndirected, false)
+	p.setBaseAddr(h.Data)
+	p.setCap(clampSpan(h.Len))
 	return p
 }
 
@@ -42,14 +37,29 @@ func (p *Byteptr) TakeStrAddr(s string) *Byteptr {
 	return p.TakeStringAddr(s)
 }
 
+// Init binds p to s and sets offset and length.
+//
+// Offset and length that don't fit the byteptr (see build tag vector_large) truncate silently, use TryInit to check.
 func (p *Byteptr) Init(s []byte, offset, len int) *Byteptr {
 	return p.TakeAddr(s).SetOffset(offset).SetLen(len)
 }
 
+// TryInit is a checked version of Init. Returns ErrSpanOverflow if offset or length doesn't fit the byteptr.
+func (p *Byteptr) TryInit(s []byte, offset, len int) error {
+	if !fitSpan(offset) || !fitSpan(len) {
+		return ErrSpanOverflow
+	}
+	p.Init(s, offset, len)
+	return nil
+}
+
+// InitRaw binds p to address addr and sets offset and length. Values that don't fit truncate silently (see Init).
 func (p *Byteptr) InitRaw(addr uintptr, offset, len int) *Byteptr {
-	p.addr = addr
-	p.offset = uint32(offset)
-	p.len = uint32(len)
+	p.bits.SetBit(bitBuffered, false)
+	p.bits.SetBit(bitIndirected, false)
+	p.setBaseAddr(addr)
+	p.offset = span(offset)
+	p.len = span(len)
 	return p
 }
 
@@ -63,44 +73,90 @@ func (p *Byteptr) InitStr(s string, offset, len int) *Byteptr {
 }
 
 func (p *Byteptr) SetAddr(addr uintptr, cap int) *Byteptr {
-	p.addr, p.cap = addr, uint32(cap)
+	p.bits.SetBit(bitBuffered, false)
+	p.bits.SetBit(bitIndirected, false)
+	p.setBaseAddr(addr)
+	p.setCap(clampSpan(cap))
 	return p
 }
 
+// SetOffset sets offset of data. Offset that doesn't fit truncates silently, use TrySetOffset to check.
+//
+// Cached result of Helper.Indirect drops (see Indirected).
 func (p *Byteptr) SetOffset(offset int) *Byteptr {
-	p.offset = uint32(offset)
+	p.offset = span(offset)
+	p.bits.SetBit(bitBuffered, false)
+	p.bits.SetBit(bitIndirected, false)
 	return p
 }
 
+// TrySetOffset is a checked version of SetOffset. Returns ErrSpanOverflow if offset doesn't fit the byteptr.
+func (p *Byteptr) TrySetOffset(offset int) error {
+	if !fitSpan(offset) {
+		return ErrSpanOverflow
+	}
+	p.SetOffset(offset)
+	return nil
+}
+
+// SetLen sets length of data. Length that doesn't fit truncates silently, use TrySetLen to check.
+//
+// Cached result of Helper.Indirect drops (see Indirected).
 func (p *Byteptr) SetLen(len int) *Byteptr {
-	p.len = uint32(len)
+	p.len = span(len)
+	p.bits.SetBit(bitIndirected, false)
 	return p
 }
 
+// TrySetLen is a checked version of SetLen. Returns ErrSpanOverflow if length doesn't fit the byteptr.
+func (p *Byteptr) TrySetLen(len int) error {
+	if !fitSpan(len) {
+		return ErrSpanOverflow
+	}
+	p.SetLen(len)
+	return nil
+}
+
 func (p *Byteptr) Offset() int { return int(p.offset) }
 func (p *Byteptr) Len() int    { return int(p.len) }
 
+// Bytes returns value bytes unescaped by helper.
+//
+// Result of Helper.Indirect caches in the byteptr, thus helper calls only once (see Indirected).
 func (p *Byteptr) Bytes() []byte {
+	b, _ := p.TryBytes()
+	return b
+}
+
+// TryBytes is a checked version of Bytes.
+//
+// Returns ErrSpanOverflow if vector's read cache grew over the byteptr offset limit and unescaped data can't be cached. In
+// that case result of Helper.Indirect returns uncached, but with flag FlagCopyUnescape raw bytes return to keep the
+// source untouched.
+func (p *Byteptr) TryBytes() ([]byte, error) {
+	if p.bits.CheckBit(bitIndirected) {
+		return p.RawBytes(), nil
+	}
 	if vec := p.indirectVector(); vec != nil && vec.Helper != nil {
-		return vec.Helper.Indirect(p)
+		if vec.CheckBit(FlagCopyUnescape) && !p.bufferize(vec) {
+			return p.RawBytes(), ErrSpanOverflow
+		}
+		return p.indirect(vec, vec.Helper.Indirect(p))
 	}
-	return p.RawBytes()
+	return p.RawBytes(), nil
 }
 
+// String returns value string unescaped by helper.
 func (p *Byteptr) String() string {
-	if vec := p.indirectVector(); vec != nil && vec.Helper != nil {
-		b := vec.Helper.Indirect(p)
-		return byteconv.B2S(b)
-	}
-	return p.RawString()
+	return byteconv.B2S(p.Bytes())
 }
 
 func (p *Byteptr) RawBytes() []byte {
-	if p.addr == 0 || p.len == 0 {
+	if p.len == 0 || p.dataAddr() == 0 {
 		return nil
 	}
 	h := byteconv.SliceHeader{
-		Data: p.addr + uintptr(p.offset),
+		Data: p.dataAddr(),
 		Len:  int(p.len),
 		Cap:  int(p.len),
 	}
@@ -108,11 +164,11 @@ func (p *Byteptr) RawBytes() []byte {
 }
 
 func (p *Byteptr) RawString() string {
-	if p.addr == 0 || p.len == 0 {
+	if p.len == 0 || p.dataAddr() == 0 {
 		return ""
 	}
 	h := byteconv.StringHeader{
-		Data: p.addr + uintptr(p.offset),
+		Data: p.dataAddr(),
 		Len:  int(p.len),
 	}
 	return *(*string)(unsafe.Pointer(&h))
@@ -122,22 +178,117 @@ func (p *Byteptr) SetBit(pos int, value bool) { p.bits.SetBit(pos, value) }
 func (p *Byteptr) CheckBit(pos int) bool      { return p.bits.CheckBit(pos) }
 
 func (p *Byteptr) Reset() {
-	p.addr = 0
-	p.vptr = 0
-	p.bits.Reset()
-	p.offset = 0
-	p.len = 0
-	p.cap = 0
+	*p = Byteptr{}
+}
+
+// Indirected checks if p already contains result of Helper.Indirect.
+//
+// Bit sets by Bytes/String methods after the first helper call and resets on data change (TakeAddr, Init*, SetAddr,
+// SetOffset, SetLen). Helpers may check it to avoid repeated unescape of the same data.
+func (p *Byteptr) Indirected() bool {
+	return p.bits.CheckBit(bitIndirected)
+}
+
+// SetIndirected marks p as already unescaped (eg by parser).
+func (p *Byteptr) SetIndirected(value bool) *Byteptr {
+	p.bits.SetBit(bitIndirected, value)
+	return p
+}
+
+// Cache result b of Helper.Indirect in p.
+//
+// In-place result just updates length of p, any other result copies to the vector's read cache.
+func (p *Byteptr) indirect(vec *Vector, b []byte) ([]byte, error) {
+	switch {
+	case len(b) == 0:
+		p.len = 0
+	case addrOf(b) == p.dataAddr() && len(b) <= int(p.len):
+		p.len = span(len(b))
+	default:
+		off := len(vec.bufRC)
+		if !fitSpan(off) || !fitSpan(len(b)) {
+			return b, ErrSpanOverflow
+		}
+		vec.savePtr(p)
+		vec.bufRC = append(vec.bufRC, b...)
+		if !p.bits.CheckBit(bitBuffered) {
+			origin := p.dataAddr()
+			p.bits.SetBit(bitBuffered, true)
+			p.setBaseAddr(origin)
+		}
+		p.offset, p.len = span(off), span(len(b))
+		b = vec.bufRC[off:]
+	}
+	p.bits.SetBit(bitIndirected, true)
+	return b, nil
+}
+
+// Buffered checks if data of p was copied to the vector's read cache (see FlagCopyUnescape).
+func (p *Byteptr) Buffered() bool {
+	return p.bits.CheckBit(bitBuffered)
+}
+
+// Copy source data of p to the vector's read cache and bind p to it.
+//
+// Data outside of source doesn't copy, since it may be modified safely. Returns false if buffer offset doesn't fit p.
+func (p *Byteptr) bufferize(vec *Vector) bool {
+	if p.len == 0 || p.bits.CheckBit(bitBuffered) {
+		return true
+	}
+	start := p.dataAddr()
+	if start == 0 || start < vec.addr || start+uintptr(p.len) > vec.addr+uintptr(len(vec.src)) {
+		return true
+	}
+	off := len(vec.bufRC)
+	if !fitSpan(off) {
+		return false
+	}
+	vec.savePtr(p)
+	vec.bufRC = append(vec.bufRC, p.RawBytes()...)
+	p.bits.SetBit(bitBuffered, true)
+	p.setBaseAddr(start)
+	p.offset = span(off)
+	return true
+}
+
+// Get address of the first byte of data.
+//
+// Address of buffered data calculates using current read cache of the vector, thus cache may grow safely.
+func (p *Byteptr) dataAddr() uintptr {
+	if p.bits.CheckBit(bitBuffered) {
+		if vec := p.indirectVector(); vec != nil && len(vec.bufRC) > 0 {
+			return addrOf(vec.bufRC) + uintptr(p.offset)
+		}
+		return p.baseAddr()
+	}
+	if addr := p.baseAddr(); addr != 0 {
+		return addr + uintptr(p.offset)
+	}
+	return 0
+}
+
+// Check if v fits offset/length fields.
+func fitSpan(v int) bool {
+	return v >= 0 && uint64(v) <= maxSpan
+}
+
+// Convert capacity to span, capacities that doesn't fit truncate to the maximum.
+func clampSpan(v int) span {
+	if !fitSpan(v) {
+		return maxSpan
+	}
+	return span(v)
 }
 
 // Restore the entire object from the unsafe pointer.
 //
 // This needs to reduce pointers count and avoids redundant GC checks.
 func (p *Byteptr) indirectVector() *Vector {
-	if p.vptr == 0 {
+	vptr := p.vectorPtr()
+	if vptr == 0 {
 		return nil
 	}
-	return (*Vector)(indirect.ToUnsafePtr(p.vptr))
+	return (*Vector)(indirect.ToUnsafePtr(vptr))
 }
 
 // Check if p equals to substring of s described by e.
@@ -12,4 +12,46 @@ func TestByteptr(t *testing.T) {
 			t.FailNow()
 		}
 	})
+	t.Run("overflow", func(t *testing.T) {
+		var p Byteptr
+		if err := p.TryInit([]byte("foobar"), 1, 3); err != nil || p.String() != "oob" {
+			t.Errorf("init failed: %v", err)
+		}
+		if err := p.TrySetLen(-1); err != ErrSpanOverflow {
+			t.Errorf("negative length must fail, got %v", err)
+		}
+		if b, err := p.TryBytes(); err != nil || string(b) != "oob" {
+			t.Errorf("bytes mismatch: %q/%v", b, err)
+		}
+		off := uint64(maxSpan) + 1
+		if err := p.TrySetOffset(int(off)); err != ErrSpanOverflow {
+			t.Errorf("offset overflow must fail, got %v", err)
+		}
+	})
+	t.Run("copy", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+		vec.Reset()
+		vec.SetHelper(testUnescapeHelper{copy: true})
+		_ = vec.SetSrc([]byte(`{"a":{"b":"foo"},"c":[1,2]}`), false)
+		testEmit(vec)
+		node := vec.Dot("a.b")
+		// Detached copies on the stack and in the heap.
+		k, v := *node.Key(), *node.Value()
+		if k.String() != "b" || v.String() != "foo" {
+			t.Errorf("copy mismatch: '%s'/'%s'", k.String(), v.String())
+		}
+		// Detached copy in the heap keeps binding.
+		hv := &struct{ p Byteptr }{p: *node.Value()}
+		if hv.p.indirectVector() != vec || hv.p.String() != "foo" {
+			t.Errorf("copy mismatch: need 'foo', got '%s'", hv.p.String())
+		}
+		// Copy of the whole node keeps binding.
+		cpy := *node
+		if s := cpy.String(); s != "foo" {
+			t.Errorf("node copy mismatch: need 'foo', got '%s'", s)
+		}
+	})
 }
@@ -0,0 +1,263 @@
+package vector
+
+import (
+	"unsafe"
+
+	"github.com/koykov/bytealg"
+	"github.com/koykov/byteconv"
+)
+
+// Memory region of the vector (source or buffer) and its offset in destination buffer.
+type region struct {
+	addr uintptr
+	len  int
+	off  int
+}
+
+// Check if region contains [addr, addr+len) completely.
+func (r *region) contains(addr uintptr, len int) bool {
+	return r.len > 0 && addr >= r.addr && addr+uintptr(len) <= r.addr+uintptr(r.len)
+}
+
+// CloneTo makes a deep copy of the vector to dst.
+//
+// Source and buffer bytes copies to the buffer of dst, then all nodes, index rows and addresses rebase to dst memory.
+// Read cache copies as is. Thus, the copy is fully independent of the original vector lifetime and may be used after vector release.
+func (vec *Vector) CloneTo(dst *Vector) error {
+	if dst == vec {
+		return ErrInternal
+	}
+	if err := vec.decodeAll(); err != nil {
+		return err
+	}
+	dst.Reset()
+
+	regs, size := vec.regions()
+	// Bytes outside of regions will be copied after them.
+	ext := size
+	size += vec.externalLen(&regs)
+
+	dst.buf = bytealg.Grow(dst.buf[:0], size)
+	for i := 0; i < len(regs); i++ {
+		if r := &regs[i]; r.len > 0 {
+			copy(dst.buf[r.off:], unsafeBytes(r.addr, r.len))
+		}
+	}
+	dst.src = dst.buf[regs[0].off : regs[0].off+regs[0].len]
+	dst.addr = addrOf(dst.src)
+	dst.selfPtr = dst.ptr()
+
+	dst.nodes = append(dst.nodes[:0], vec.nodes[:vec.nodeL]...)
+	for i := 0; i < vec.nodeL; i++ {
+		node := &dst.nodes[i]
+		ext = rebase(&regs, &node.key, dst, ext)
+		ext = rebase(&regs, &node.val, dst, ext)
+		node.bind(dst.selfPtr)
+	}
+	dst.nodeL = vec.nodeL
+	vec.Index.cloneTo(&dst.Index)
+	// Buffered data keeps offsets in the read cache.
+	dst.bufRC = append(dst.bufRC[:0], vec.bufRC...)
+
+	dst.errOff = vec.errOff
+	dst.Helper = vec.Helper
+	dst.Bitset = vec.Bitset
+	dst.tls = append(dst.tls[:0], vec.tls...)
+	dst.benc = vec.benc
+	dst.btrue = append(dst.btrue[:0], vec.btrue...)
+	dst.bfalse = append(dst.bfalse[:0], vec.bfalse...)
+	dst.tb.stack = append(dst.tb.stack[:0], vec.tb.stack...)
+	return nil
+}
+
+// ExtractTo copies the node and all its children to dst as a new root.
+//
+// Bytes of keys and values copies to the buffer of dst, depths of nodes are shifted accordingly. Thus, subtree may be
+// used independently of the original vector lifetime.
+func (n *Node) ExtractTo(dst *Vector) error {
+	vec := n.indirectVector()
+	if vec == nil || vec == dst {
+		return ErrInternal
+	}
+	dst.Reset()
+	size := treeLen(vec, n)
+	dst.buf = bytealg.Grow(dst.buf[:0], size)
+	dst.src = dst.buf
+	dst.addr = addrOf(dst.buf)
+	dst.selfPtr = dst.ptr()
+	dst.Helper = vec.Helper
+	dst.Bitset = vec.Bitset
+	dst.tls = append(dst.tls[:0], vec.tls...)
+	dst.benc = vec.benc
+	dst.btrue = append(dst.btrue[:0], vec.btrue...)
+	dst.bfalse = append(dst.bfalse[:0], vec.bfalse...)
+	var pos int
+	extract(dst, vec, n, 0, -1, &pos)
+	return nil
+}
+
+// Calculate total length of keys and values of the subtree.
+func treeLen(vec *Vector, n *Node) int {
+	size := n.key.Len() + n.val.Len()
+	if n.typ == TypeObject || n.typ == TypeArray || n.typ == TypeAlias {
+		ci := n.childrenIdx()
+		for i := 0; i < len(ci); i++ {
+			size += treeLen(vec, &vec.nodes[ci[i]])
+		}
+	}
+	return size
+}
+
+// Copy node n of vec to dst.
+func extract(dst, vec *Vector, n *Node, depth, parent int, pos *int) {
+	node, idx := dst.ackNode(depth)
+	node.typ = n.typ
+	node.pidx = nint(parent)
+	limit := dst.Index.Register(depth, idx)
+	if parent >= 0 {
+		dst.nodes[parent].SetLimit(limit)
+	}
+	*pos = copyBytes(&node.key, &n.key, dst.buf, *pos)
+	*pos = copyBytes(&node.val, &n.val, dst.buf, *pos)
+	if n.typ != TypeObject && n.typ != TypeArray && n.typ != TypeAlias {
+		return
+	}
+	off := dst.Index.Len(depth + 1)
+	node.SetOffset(off).SetLimit(off)
+	ci := n.childrenIdx()
+	for i := 0; i < len(ci); i++ {
+		extract(dst, vec, &vec.nodes[ci[i]], depth+1, idx, pos)
+	}
+}
+
+// Copy bytes of src to buf at position pos and bind dst to them.
+func copyBytes(dst, src *Byteptr, buf []byte, pos int) int {
+	vptr := dst.vectorPtr()
+	*dst = *src
+	dst.bind(0)
+	dst.bits.SetBit(bitBuffered, false)
+	var n int
+	if src.len == 0 {
+		dst.setBaseAddr(0)
+		dst.setCap(0)
+		dst.offset = 0
+	} else {
+		n = copy(buf[pos:], src.RawBytes())
+		dst.setBaseAddr(addrOf(buf))
+		dst.setCap(clampSpan(len(buf)))
+		dst.offset, dst.len = span(pos), span(n)
+	}
+	dst.bind(vptr)
+	return pos + n
+}
+
+// Describe source and buffer regions and their layout in the destination buffer.
+//
+// Buffer may contain the source (see ParseCopy), in that case the source region will be placed inside buffer region.
+func (vec *Vector) regions() (regs [2]region, size int) {
+	regs[0] = region{addr: vec.addr, len: len(vec.src)}
+	regs[1] = region{addr: addrOf(vec.buf), len: len(vec.buf), off: len(vec.src)}
+	if regs[1].contains(regs[0].addr, regs[0].len) {
+		regs[0].off = int(regs[0].addr - regs[1].addr)
+		regs[1].off = 0
+	}
+	size = regs[1].off + regs[1].len
+	if regs[0].off+regs[0].len > size {
+		size = regs[0].off + regs[0].len
+	}
+	return
+}
+
+// Get total length of nodes data outside of regs.
+func (vec *Vector) externalLen(regs *[2]region) (n int) {
+	for i := 0; i < vec.nodeL; i++ {
+		node := &vec.nodes[i]
+		n += externalLen(regs, &node.key) + externalLen(regs, &node.val)
+	}
+	return
+}
+
+// Get length of p's data outside of regs.
+func externalLen(regs *[2]region, p *Byteptr) int {
+	if p.len == 0 || p.bits.CheckBit(bitBuffered) {
+		return 0
+	}
+	start := p.dataAddr()
+	if start == 0 {
+		return 0
+	}
+	if regs[0].contains(start, int(p.len)) || regs[1].contains(start, int(p.len)) {
+		return 0
+	}
+	return int(p.len)
+}
+
+// Locate p's data in the destination layout described by regs.
+//
+// Returns position of p's address and p's offset relative to it. Data outside of regions locates at position ext.
+func locate(regs *[2]region, p *Byteptr, ext int) (pos int, offset span, external bool) {
+	addr, start := p.baseAddr(), p.dataAddr()
+	for i := 0; i < len(regs); i++ {
+		if r := &regs[i]; r.contains(start, int(p.len)) {
+			if addr >= r.addr {
+				return r.off + int(addr-r.addr), p.offset, false
+			}
+			return r.off + int(start-r.addr), 0, false
+		}
+	}
+	return ext, 0, true
+}
+
+// Rebase p from regs to the buffer of dst and bind it to dst.
+//
+// Data outside of regions copies to the buffer at position ext. Returns the next external position.
+func rebase(regs *[2]region, p *Byteptr, dst *Vector, ext int) int {
+	base := addrOf(dst.buf)
+	if p.len > 0 && p.bits.CheckBit(bitBuffered) {
+		// Buffered data is in the copy of read cache, only the original location rebases.
+		origin := p.baseAddr()
+		p.bind(dst.selfPtr)
+		if r := &regs[0]; r.contains(origin, int(p.len)) {
+			p.setBaseAddr(base + uintptr(r.off) + (origin - r.addr))
+		} else {
+			p.setBaseAddr(0)
+		}
+		return ext
+	}
+	var pos int
+	var offset span
+	if p.len > 0 && p.dataAddr() != 0 {
+		var external bool
+		if pos, offset, external = locate(regs, p, ext); external {
+			n := copy(dst.buf[ext:], p.RawBytes())
+			p.setCap(span(n))
+			ext += n
+		}
+	}
+	p.bind(0)
+	if p.len == 0 || p.dataAddr() == 0 {
+		p.setBaseAddr(0)
+		p.setCap(0)
+		p.offset = 0
+	} else {
+		p.setBaseAddr(base + uintptr(pos))
+		p.offset = offset
+	}
+	p.bind(dst.selfPtr)
+	return ext
+}
+
+// Get address of the first byte of p.
+func addrOf(p []byte) uintptr {
+	if cap(p) == 0 {
+		return 0
+	}
+	h := (*byteconv.SliceHeader)(unsafe.Pointer(&p))
+	return h.Data
+}
+
+// Make bytes slice of given address and length.
+func unsafeBytes(addr uintptr, len int) []byte {
+	h := byteconv.SliceHeader{Data: addr, Len: len, Cap: len}
+	return *(*[]byte)(unsafe.Pointer(&h))
+}
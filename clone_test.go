@@ -0,0 +1,58 @@
+package vector
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	assert := func(t *testing.T, vec *Vector, path, expect string) {
+		if s := vec.Dot(path).String(); s != expect {
+			t.Errorf("value mismatch of '%s': need '%s', got '%s'", path, expect, s)
+		}
+	}
+	t.Run("clone", func(t *testing.T) {
+		vec, cpy := testPool.Get().(*Vector), testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer testPool.Put(cpy)
+		defer cpy.Reset()
+		vec.Reset()
+
+		_ = vec.SetSrc([]byte(src), true)
+		testEmit(vec)
+		vec.Dot("c.0").Value().Init(vec.BufferizeString("15"), 0, 2)
+		vec.Dot("a.b").Value().InitString("bar", 0, 3)
+		if err := vec.CloneTo(cpy); err != nil {
+			t.Fatal(err)
+		}
+		vec.Reset()
+		_ = vec.SetSrc([]byte(`{"x":"yyyyyyyyyyyyyyyyyyyyyyyyyyyyyyy"}`), true)
+
+		assert(t, cpy, "a.b", "bar")
+		assert(t, cpy, "c.0", "15")
+		assert(t, cpy, "c.1", "2")
+		if string(cpy.Src()) != src {
+			t.Errorf("source mismatch: %s", cpy.Src())
+		}
+	})
+	t.Run("extract", func(t *testing.T) {
+		vec, sub := testPool.Get().(*Vector), testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer testPool.Put(sub)
+		defer sub.Reset()
+		vec.Reset()
+
+		_ = vec.SetSrc([]byte(src), true)
+		testEmit(vec)
+		if err := vec.Dot("c").ExtractTo(sub); err != nil {
+			t.Fatal(err)
+		}
+		vec.Reset()
+
+		if sub.Len() != 3 || sub.Root().Type() != TypeArray || sub.Root().Depth() != 0 {
+			t.Errorf("subtree mismatch")
+		}
+		assert(t, sub, "1", "2")
+		if sub.Root().KeyString() != "c" {
+			t.Errorf("root key mismatch")
+		}
+	})
+}
@@ -24,6 +24,8 @@ func equal(a, b *Node) bool {
 	if a.Type() != b.Type() {
 		return false
 	}
+	a.expand()
+	b.expand()
 	if a.Limit() != b.Limit() {
 		return false
 	}
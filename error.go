@@ -14,6 +14,16 @@ var (
 	ErrUnexpId      = errors.New("unexpected identifier")
 	ErrUnexpEOF     = errors.New("unexpected end of file")
 	ErrUnexpEOS     = errors.New("unexpected end of string")
+	ErrUnbalanced   = errors.New("unbalanced container end")
+
+	ErrInterestOverflow = errors.New("too many paths in interest set")
+	ErrBadSavepoint     = errors.New("savepoint is invalid or already released")
+	ErrSnapshotCorrupt  = errors.New("snapshot is corrupt or truncated")
+	ErrSnapshotVersion  = errors.New("unsupported snapshot version")
+	ErrSpanOverflow     = errors.New("offset or length overflows byteptr")
+	ErrOverflow         = errors.New("value overflows requested type")
+	ErrBadNumber        = errors.New("malformed number")
+	ErrBadDuration      = errors.New("malformed duration")
 
 	_, _, _, _, _ = ErrShortSrc, ErrUnparsedTail, ErrUnexpId, ErrUnexpEOF, ErrUnexpEOS
 )
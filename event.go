@@ -0,0 +1,257 @@
+package vector
+
+import "github.com/koykov/indirect"
+
+// EventHandler describes SAX-style parsing callbacks.
+//
+// Format parsers may report parsed tokens using Emit* methods of the vector instead of direct nodes acquiring. Thus, the
+// same parser code may build the nodes tree (see TreeBuilder) or stream values to custom handler without nodes
+// materialization.
+type EventHandler interface {
+	// OnObjectStart calls on object begin.
+	OnObjectStart() error
+	// OnArrayStart calls on array begin.
+	OnArrayStart() error
+	// OnKey calls before each child of the object.
+	OnKey(key *Byteptr) error
+	// OnScalar calls for each scalar value (string, number, bool, null, attribute, ...).
+	OnScalar(typ Type, raw *Byteptr) error
+	// OnEnd calls on end of current object or array.
+	OnEnd() error
+}
+
+// OffsetEventHandler is an optional extension of EventHandler that receives source offsets of containers.
+//
+// See Vector.EmitObjectStartAt, EmitArrayStartAt and EmitEndAt.
+type OffsetEventHandler interface {
+	// OnObjectStartAt calls on object begin with offset of the opening delimiter in the source.
+	OnObjectStartAt(offset int) error
+	// OnArrayStartAt calls on array begin with offset of the opening delimiter in the source.
+	OnArrayStartAt(offset int) error
+	// OnEndAt calls on end of current object or array with offset next to the closing delimiter.
+	OnEndAt(offset int) error
+}
+
+// TreeBuilder is an EventHandler implementation that builds nodes tree of the vector from events.
+//
+// Each vector has own builder instance and uses it by default (see Vector.SetEventHandler).
+type TreeBuilder struct {
+	// Raw pointer to the vector.
+	vptr uintptr
+	// Stack of indices of open containers.
+	stack []int
+	// Pending key of the next node.
+	key   Byteptr
+	keyOK bool
+}
+
+// NewTreeBuilder makes new builder of given vector.
+func NewTreeBuilder(vec *Vector) *TreeBuilder {
+	b := &TreeBuilder{}
+	return b.Bind(vec)
+}
+
+// Bind binds builder to the vector.
+func (b *TreeBuilder) Bind(vec *Vector) *TreeBuilder {
+	b.vptr = vec.ptr()
+	return b
+}
+
+func (b *TreeBuilder) OnObjectStart() error {
+	return b.open(TypeObject, -1)
+}
+
+func (b *TreeBuilder) OnArrayStart() error {
+	return b.open(TypeArray, -1)
+}
+
+// OnObjectStartAt opens object and binds its value to the source starting from offset.
+//
+// Value of the container will cover the whole container after OnEndAt call (see Node.SourceSpan).
+func (b *TreeBuilder) OnObjectStartAt(offset int) error {
+	return b.open(TypeObject, offset)
+}
+
+// OnArrayStartAt opens array and binds its value to the source starting from offset.
+func (b *TreeBuilder) OnArrayStartAt(offset int) error {
+	return b.open(TypeArray, offset)
+}
+
+func (b *TreeBuilder) OnKey(key *Byteptr) error {
+	b.key.copyFrom(key)
+	b.keyOK = true
+	return nil
+}
+
+func (b *TreeBuilder) OnScalar(typ Type, raw *Byteptr) error {
+	vec := b.indirectVector()
+	if vec == nil {
+		return ErrInternal
+	}
+	node := b.acquire(vec, typ)
+	node.val.copyFrom(raw)
+	return nil
+}
+
+func (b *TreeBuilder) OnEnd() error {
+	if len(b.stack) == 0 {
+		return ErrUnbalanced
+	}
+	b.stack = b.stack[:len(b.stack)-1]
+	return nil
+}
+
+// OnEndAt closes current container and sets the end of its value to offset.
+func (b *TreeBuilder) OnEndAt(offset int) error {
+	if len(b.stack) == 0 {
+		return ErrUnbalanced
+	}
+	vec := b.indirectVector()
+	if vec == nil {
+		return ErrInternal
+	}
+	if offset > len(vec.src) {
+		return ErrShortSrc
+	}
+	node := &vec.nodes[b.stack[len(b.stack)-1]]
+	if lo := node.val.Offset(); node.val.baseAddr() != 0 && offset >= lo {
+		if err := node.val.TrySetLen(offset - lo); err != nil {
+			return err
+		}
+	}
+	return b.OnEnd()
+}
+
+// Depth returns current depth of the builder.
+func (b *TreeBuilder) Depth() int {
+	return len(b.stack)
+}
+
+// Reset builder state.
+func (b *TreeBuilder) Reset() {
+	b.stack = b.stack[:0]
+	b.key.Reset()
+	b.keyOK = false
+}
+
+// Open new container node and push it to the stack.
+//
+// Non-negative pos binds value of the container to the source starting from pos.
+func (b *TreeBuilder) open(typ Type, pos int) error {
+	vec := b.indirectVector()
+	if vec == nil {
+		return ErrInternal
+	}
+	if pos >= len(vec.src) {
+		return ErrShortSrc
+	}
+	node := b.acquire(vec, typ)
+	off := vec.Index.Len(int(node.depth) + 1)
+	node.SetOffset(off).SetLimit(off)
+	if pos >= 0 {
+		if err := node.val.TryInit(vec.src, pos, 0); err != nil {
+			return err
+		}
+	}
+	b.stack = append(b.stack, int(node.idx))
+	return nil
+}
+
+// Acquire new node on current depth and register it as child of the top container.
+//
+// Parent node takes from the array by index each time since nodes array may grow during acquiring.
+func (b *TreeBuilder) acquire(vec *Vector, typ Type) *Node {
+	depth := len(b.stack)
+	node, idx := vec.ackNode(depth)
+	node.typ = typ
+	limit := vec.Index.Register(depth, idx)
+	if depth > 0 {
+		vec.nodes[b.stack[depth-1]].SetLimit(limit)
+		node.pidx = nint(b.stack[depth-1])
+	}
+	if b.keyOK {
+		node.key.copyFrom(&b.key)
+		b.keyOK = false
+	}
+	return node
+}
+
+// Restore the entire vector object from the unsafe pointer.
+func (b *TreeBuilder) indirectVector() *Vector {
+	if b.vptr == 0 {
+		return nil
+	}
+	return (*Vector)(indirect.ToUnsafePtr(b.vptr))
+}
+
+// SetEventHandler sets custom events handler.
+//
+// Nil handler enables the default mode - events will be converted to nodes using built-in TreeBuilder.
+func (vec *Vector) SetEventHandler(handler EventHandler) {
+	vec.evh = handler
+}
+
+// EventHandler returns current events handler.
+func (vec *Vector) EventHandler() EventHandler {
+	if vec.evh != nil {
+		return vec.evh
+	}
+	vec.tb.Bind(vec)
+	return &vec.tb
+}
+
+// EmitObjectStart reports object begin.
+func (vec *Vector) EmitObjectStart() error {
+	return vec.EventHandler().OnObjectStart()
+}
+
+// EmitArrayStart reports array begin.
+func (vec *Vector) EmitArrayStart() error {
+	return vec.EventHandler().OnArrayStart()
+}
+
+// EmitKey reports key of the next object child.
+func (vec *Vector) EmitKey(key *Byteptr) error {
+	key.bind(vec.selfPtr)
+	return vec.EventHandler().OnKey(key)
+}
+
+// EmitScalar reports scalar value.
+func (vec *Vector) EmitScalar(typ Type, raw *Byteptr) error {
+	raw.bind(vec.selfPtr)
+	return vec.EventHandler().OnScalar(typ, raw)
+}
+
+// EmitEnd reports end of current object or array.
+func (vec *Vector) EmitEnd() error {
+	return vec.EventHandler().OnEnd()
+}
+
+// EmitObjectStartAt reports object begin with offset of the opening delimiter in the source.
+//
+// Handlers that don't implement OffsetEventHandler receive regular OnObjectStart event.
+func (vec *Vector) EmitObjectStartAt(offset int) error {
+	h := vec.EventHandler()
+	if oh, ok := h.(OffsetEventHandler); ok {
+		return oh.OnObjectStartAt(offset)
+	}
+	return h.OnObjectStart()
+}
+
+// EmitArrayStartAt reports array begin with offset of the opening delimiter in the source.
+func (vec *Vector) EmitArrayStartAt(offset int) error {
+	h := vec.EventHandler()
+	if oh, ok := h.(OffsetEventHandler); ok {
+		return oh.OnArrayStartAt(offset)
+	}
+	return h.OnArrayStart()
+}
+
+// EmitEndAt reports end of current object or array with offset next to the closing delimiter.
+func (vec *Vector) EmitEndAt(offset int) error {
+	h := vec.EventHandler()
+	if oh, ok := h.(OffsetEventHandler); ok {
+		return oh.OnEndAt(offset)
+	}
+	return h.OnEnd()
+}
@@ -0,0 +1,69 @@
+package vector
+
+import "testing"
+
+type testEventCounter struct {
+	containers, keys, scalars, ends int
+}
+
+func (c *testEventCounter) OnObjectStart() error              { c.containers++; return nil }
+func (c *testEventCounter) OnArrayStart() error               { c.containers++; return nil }
+func (c *testEventCounter) OnKey(_ *Byteptr) error            { c.keys++; return nil }
+func (c *testEventCounter) OnScalar(_ Type, _ *Byteptr) error { c.scalars++; return nil }
+func (c *testEventCounter) OnEnd() error                      { c.ends++; return nil }
+
+// Emulate parsing of `{"a":{"b":"foo"},"c":[1,2]}`.
+func testEmit(vec *Vector) {
+	src := vec.Src()
+	var p Byteptr
+	_ = vec.EmitObjectStart()
+	_ = vec.EmitKey(p.Init(src, 2, 1))
+	_ = vec.EmitObjectStart()
+	_ = vec.EmitKey(p.Init(src, 7, 1))
+	_ = vec.EmitScalar(TypeString, p.Init(src, 11, 3))
+	_ = vec.EmitEnd()
+	_ = vec.EmitKey(p.Init(src, 18, 1))
+	_ = vec.EmitArrayStart()
+	_ = vec.EmitScalar(TypeNumber, p.Init(src, 22, 1))
+	_ = vec.EmitScalar(TypeNumber, p.Init(src, 24, 1))
+	_ = vec.EmitEnd()
+	_ = vec.EmitEnd()
+}
+
+func TestEvent(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	t.Run("tree", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.Reset()
+
+		_ = vec.SetSrc([]byte(src), true)
+		testEmit(vec)
+		if vec.Len() != 6 {
+			t.Errorf("nodes count mismatch: need 6, got %d", vec.Len())
+		}
+		if s := vec.DotString("a.b"); s != "foo" {
+			t.Errorf("value mismatch: need 'foo', got '%s'", s)
+		}
+		if i, _ := vec.DotInt("c.1"); i != 2 {
+			t.Errorf("value mismatch: need 2, got %d", i)
+		}
+	})
+	t.Run("handler", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.Reset()
+
+		var c testEventCounter
+		vec.SetEventHandler(&c)
+		defer vec.SetEventHandler(nil)
+		_ = vec.SetSrc([]byte(src), true)
+		testEmit(vec)
+		if vec.Len() != 0 {
+			t.Errorf("nodes must not be materialized, got %d", vec.Len())
+		}
+		if c.containers != 3 || c.keys != 3 || c.scalars != 3 || c.ends != 3 {
+			t.Errorf("events count mismatch: %+v", c)
+		}
+	})
+}
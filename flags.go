@@ -7,4 +7,36 @@ const (
 	FlagNoClear
 	// FlagExtraBool enables YAML style bool check [On, Off] in addition to [true, false].
 	FlagExtraBool
+	// FlagLazy enables lazy mode - parsers record container nodes as raw source spans and parse their children on
+	// demand (see LazyHelper).
+	FlagLazy
+	// FlagCopyUnescape makes unescape to write into vector's read cache instead of source. Source bytes stay untouched.
+	FlagCopyUnescape
+	// FlagLenient enables type coercion in typed getters (see Get), eg "42" may be read as int and 1 as bool.
+	FlagLenient
+	// FlagNumUnderscore allows underscores between digits of numbers, eg 1_000_000.
+	FlagNumUnderscore
+	// FlagNumPrefix allows hex, octal and binary prefixes of integers (0x, 0o, 0b).
+	FlagNumPrefix
+	// FlagNumInfNaN allows Inf and NaN float values.
+	FlagNumInfNaN
+)
+
+// Byteptr bits reserved by vector API. Helpers may use lower bits for their own needs.
+const (
+	// Marks value of the container node as raw source span with unparsed children.
+	bitDeferred = 31
+	// Marks value of the container node loaded from memory mapped snapshot with undecoded children.
+	bitMapped = 30
+	// Marks byteptr bound to the vector and data base relative to the vector's buffer (compact layout only).
+	bitBound   = 29
+	bitBaseBuf = 28
+	// Marks byteptr data copied to the vector's read cache (see FlagCopyUnescape). Offset is relative to the cache and
+	// address keeps the original location of data in the source.
+	bitBuffered = 27
+	// Marks byteptr that already contains result of Helper.Indirect.
+	bitIndirected = 26
+
+	// Bits that describe state of byteptr data and must be cleared on data change.
+	bitsReserved = 1<<bitDeferred | 1<<bitMapped | 1<<bitBuffered | 1<<bitIndirected
 )
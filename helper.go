@@ -12,7 +12,23 @@ type Helper interface {
 	Marshal(io.Writer, *Node) error
 }
 
+// CopyUnescapeHelper is an optional extension of Helper that allows to request unescape into vector's buffer (see
+// FlagCopyUnescape).
+type CopyUnescapeHelper interface {
+	// CopyUnescape returns true if helper requires immutable source.
+	CopyUnescape() bool
+}
+
 // SetHelper sets helper to vector object.
 func (vec *Vector) SetHelper(helper Helper) {
 	vec.Helper = helper
+	vec.setHelperFlags()
+}
+
+// Set flags depending on helper.
+func (vec *Vector) setHelperFlags() {
+	vec.SetBit(FlagInit, vec.Helper != nil)
+	if h, ok := vec.Helper.(CopyUnescapeHelper); ok && h.CopyUnescape() {
+		vec.SetBit(FlagCopyUnescape, true)
+	}
 }
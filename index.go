@@ -52,12 +52,15 @@ func (idx *Index) GetRow(depth int) []int {
 }
 
 // Reset rest of the index starting of given depth and offset in the tree.
+//
+// Note, all rows deeper than depth will be reset entirely. Use Vector.Savepoint/Vector.Rollback to restore the exact
+// state of the index.
 func (idx *Index) Reset(depth, offset int) {
 	if depth >= len(idx.tree) {
 		return
 	}
 	if idx.tree[depth].len > offset {
-		idx.tree[depth].buf = idx.tree[depth].buf[:offset]
+		idx.tree[depth].len = offset
 	}
 	if depth+1 < len(idx.tree) {
 		for i := depth + 1; i < len(idx.tree); i++ {
@@ -80,6 +83,42 @@ func (idx *Index) val(depth, i int) int {
 	return idx.tree[depth].buf[i]
 }
 
+// Append lengths of all rows to dst.
+func (idx *Index) appendLens(dst []int) []int {
+	for i := 0; i < len(idx.tree); i++ {
+		dst = append(dst, idx.tree[i].len)
+	}
+	return dst
+}
+
+// Restore lengths of rows. Rows missing in lens will be reset.
+func (idx *Index) restoreLens(lens []int) {
+	for i := 0; i < len(idx.tree); i++ {
+		var l int
+		if i < len(lens) {
+			l = lens[i]
+		}
+		idx.tree[i].len = l
+	}
+}
+
+// Copy all rows to dst index.
+func (idx *Index) cloneTo(dst *Index) {
+	for len(dst.tree) < len(idx.tree) {
+		dst.tree = append(dst.tree, branch{})
+	}
+	for i := 0; i < len(dst.tree); i++ {
+		b := &dst.tree[i]
+		if i < len(idx.tree) {
+			b.buf = append(b.buf[:0], idx.tree[i].buf[:idx.tree[i].len]...)
+			b.len = idx.tree[i].len
+		} else {
+			b.len = 0
+		}
+	}
+	dst.depth = idx.depth
+}
+
 // Reset index object.
 func (idx *Index) reset() {
 	for i := 0; i < len(idx.tree); i++ {
@@ -0,0 +1,136 @@
+package vector
+
+import (
+	"strconv"
+
+	"github.com/koykov/entry"
+)
+
+// Path represents a path to the node with "." separator, e.g. "foo.bar[1].baz".
+type Path string
+
+// Max count of paths in interest set.
+const interestLimit = 64
+
+// Interest set of paths.
+//
+// Parsers checks it during descending and skip subtrees that aren't covered by any of paths.
+type interest struct {
+	// Raw paths.
+	paths []Path
+	// Split keys of all paths.
+	keys []entry.Entry64
+	// Bounds of keys of each path in keys array.
+	bounds []entry.Entry64
+	// Masks of paths alive at each depth.
+	mask []uint64
+}
+
+// SetInterest sets the list of paths to parse.
+//
+// Parsers may query that set during descending (see Interested and InterestedIndex) and skip subtrees outside the set,
+// thus nodes array and index will contain only given branches and their ancestors. Key "*" matches any key on its level.
+// Call without arguments disables interest set. Interest set keeps after Reset, like helper.
+func (vec *Vector) SetInterest(paths ...Path) error {
+	if len(paths) > interestLimit {
+		return ErrInterestOverflow
+	}
+	vec.ints.reset()
+	for i := 0; i < len(paths); i++ {
+		lo := uint32(len(vec.ints.keys))
+		vec.ints.keys = vec.appendSplitPathShort(vec.ints.keys, string(paths[i]), ".")
+		hi := uint32(len(vec.ints.keys))
+		vec.ints.bounds = append(vec.ints.bounds, entry.NewEntry64(lo, hi))
+		vec.ints.paths = append(vec.ints.paths, paths[i])
+	}
+	return nil
+}
+
+// HasInterest checks if interest set is defined.
+func (vec *Vector) HasInterest() bool {
+	return len(vec.ints.paths) > 0
+}
+
+// Interested checks if node with given key on given depth is covered by interest set.
+//
+// Parsers must call it for each node during descending since the result depends on the results of previous depths.
+// The root depth (0) is always interested. If interest set isn't defined, all nodes are interested.
+func (vec *Vector) Interested(depth int, key []byte) bool {
+	return vec.ints.check(depth, key)
+}
+
+// InterestedIndex checks if array item with given index on given depth is covered by interest set.
+func (vec *Vector) InterestedIndex(depth, index int) bool {
+	if len(vec.ints.paths) == 0 {
+		return true
+	}
+	var buf [20]byte
+	return vec.ints.check(depth, strconv.AppendInt(buf[:0], int64(index), 10))
+}
+
+func (i *interest) check(depth int, key []byte) bool {
+	n := len(i.paths)
+	if n == 0 {
+		return true
+	}
+	for len(i.mask) <= depth {
+		i.mask = append(i.mask, 0)
+	}
+	if depth == 0 {
+		i.mask[0] = ^uint64(0) >> (interestLimit - n)
+		return true
+	}
+	pmask, mask := i.mask[depth-1], uint64(0)
+	for j := 0; j < n; j++ {
+		if pmask&(1<<j) == 0 {
+			continue
+		}
+		lo, hi := i.bounds[j].Decode()
+		if int(hi-lo) < depth {
+			// Path fully matched on previous depths, so whole subtree is interested.
+			mask |= 1 << j
+			continue
+		}
+		klo, khi := i.keys[int(lo)+depth-1].Decode()
+		if pk := i.paths[j][klo:khi]; pk == "*" || string(pk) == string(key) {
+			mask |= 1 << j
+		}
+	}
+	i.mask[depth] = mask
+	return mask != 0
+}
+
+func (i *interest) reset() {
+	i.paths = i.paths[:0]
+	i.keys = i.keys[:0]
+	i.bounds = i.bounds[:0]
+	i.mask = i.mask[:0]
+}
+
+// SkipContainer returns the position next to closing bracket of the container started at offset.
+//
+// Brackets inside quoted strings are ignored, quote may be escaped using backslash. Zero quote disables strings check.
+// Returns -1 if the container isn't closed.
+func SkipContainer(src []byte, offset int, open, close, quote byte) int {
+	n := len(src)
+	if offset < 0 || offset >= n || src[offset] != open {
+		return -1
+	}
+	_ = src[n-1]
+	level := 0
+	for i := offset; i < n; i++ {
+		switch c := src[i]; {
+		case quote != 0 && c == quote:
+			if i = IndexByteAtNE(src, quote, i+1); i < 0 {
+				return -1
+			}
+		case c == open:
+			level++
+		case c == close:
+			if level--; level == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,56 @@
+package vector
+
+import "testing"
+
+func TestInterest(t *testing.T) {
+	t.Run("check", func(t *testing.T) {
+		var vec Vector
+		_ = vec.SetInterest("a.b", "c[1]", "d.*.e")
+		type stage struct {
+			depth  int
+			key    string
+			expect bool
+		}
+		stages := []stage{
+			{0, "", true},
+			{1, "a", true},
+			{2, "x", false},
+			{2, "b", true},
+			{3, "any", true},
+			{1, "c", true},
+			{2, "0", false},
+			{2, "1", true},
+			{1, "d", true},
+			{2, "foo", true},
+			{3, "e", true},
+			{3, "f", false},
+			{1, "z", false},
+		}
+		for _, stg := range stages {
+			if r := vec.Interested(stg.depth, []byte(stg.key)); r != stg.expect {
+				t.Errorf("interest mismatch on %d/%s: need %t, got %t", stg.depth, stg.key, stg.expect, r)
+			}
+		}
+	})
+	t.Run("reset", func(t *testing.T) {
+		var vec Vector
+		_ = vec.SetInterest("a.b")
+		vec.Reset()
+		if !vec.HasInterest() || vec.Interested(0, nil) && vec.Interested(1, []byte("x")) {
+			t.Error("interest set must keep after reset")
+		}
+		_ = vec.SetInterest()
+		if vec.HasInterest() || !vec.Interested(1, []byte("x")) {
+			t.Error("interest set must be disabled")
+		}
+	})
+	t.Run("skip", func(t *testing.T) {
+		src := []byte(`{"a":{"b":"}{"},"c":[{}]} tail`)
+		if i := SkipContainer(src, 0, '{', '}', '"'); i != 25 {
+			t.Errorf("skip mismatch: need 25, got %d", i)
+		}
+		if i := SkipContainer(src[:20], 0, '{', '}', '"'); i != -1 {
+			t.Errorf("skip mismatch: need -1, got %d", i)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package vector
 
-import "io"
+import (
+	"io"
+	"math/big"
+	"time"
+)
 
 type Interface interface {
 	// SetHelper provides Helper to escape/unescape strings.
@@ -60,6 +64,30 @@ type Interface interface {
 	GetInt(keys ...string) (int64, error)
 	// GetUint looks and get unsigned integer value by given keys.
 	GetUint(keys ...string) (uint64, error)
+	// GetInt8 looks and get 8-bit integer value by given keys.
+	GetInt8(keys ...string) (int8, error)
+	// GetInt16 looks and get 16-bit integer value by given keys.
+	GetInt16(keys ...string) (int16, error)
+	// GetInt32 looks and get 32-bit integer value by given keys.
+	GetInt32(keys ...string) (int32, error)
+	// GetUint8 looks and get 8-bit unsigned integer value by given keys.
+	GetUint8(keys ...string) (uint8, error)
+	// GetUint16 looks and get 16-bit unsigned integer value by given keys.
+	GetUint16(keys ...string) (uint16, error)
+	// GetUint32 looks and get 32-bit unsigned integer value by given keys.
+	GetUint32(keys ...string) (uint32, error)
+	// GetBigInt looks and get arbitrary-precision integer value by given keys.
+	GetBigInt(keys ...string) (*big.Int, error)
+	// GetBigFloat looks and get arbitrary-precision float value by given keys.
+	GetBigFloat(prec uint, keys ...string) (*big.Float, error)
+	// GetDecimal looks and get lossless decimal value by given keys.
+	GetDecimal(keys ...string) (Decimal, error)
+	// GetTime looks and get time value by given keys using default layouts (see Vector.SetTimeLayouts).
+	GetTime(keys ...string) (time.Time, error)
+	// GetUnix looks and get time from numeric epoch value by given keys.
+	GetUnix(unit time.Duration, keys ...string) (time.Time, error)
+	// GetDuration looks and get duration value by given keys.
+	GetDuration(keys ...string) (time.Duration, error)
 
 	// Getters by path/separator (PS) group.
 	// Note, the NULL node will return if node doesn't exist by given keys.
@@ -82,6 +110,30 @@ type Interface interface {
 	GetIntPS(path, separator string) (int64, error)
 	// GetUintPS looks and get unsigned integer value by given path and separator.
 	GetUintPS(path, separator string) (uint64, error)
+	// GetInt8PS looks and get 8-bit integer value by given path and separator.
+	GetInt8PS(path, separator string) (int8, error)
+	// GetInt16PS looks and get 16-bit integer value by given path and separator.
+	GetInt16PS(path, separator string) (int16, error)
+	// GetInt32PS looks and get 32-bit integer value by given path and separator.
+	GetInt32PS(path, separator string) (int32, error)
+	// GetUint8PS looks and get 8-bit unsigned integer value by given path and separator.
+	GetUint8PS(path, separator string) (uint8, error)
+	// GetUint16PS looks and get 16-bit unsigned integer value by given path and separator.
+	GetUint16PS(path, separator string) (uint16, error)
+	// GetUint32PS looks and get 32-bit unsigned integer value by given path and separator.
+	GetUint32PS(path, separator string) (uint32, error)
+	// GetBigIntPS looks and get arbitrary-precision integer value by given path and separator.
+	GetBigIntPS(path, separator string) (*big.Int, error)
+	// GetBigFloatPS looks and get arbitrary-precision float value by given path and separator.
+	GetBigFloatPS(prec uint, path, separator string) (*big.Float, error)
+	// GetDecimalPS looks and get lossless decimal value by given path and separator.
+	GetDecimalPS(path, separator string) (Decimal, error)
+	// GetTimePS looks and get time value by given path and separator using default layouts (see Vector.SetTimeLayouts).
+	GetTimePS(path, separator string) (time.Time, error)
+	// GetUnixPS looks and get time from numeric epoch value by given path and separator.
+	GetUnixPS(path, separator string, unit time.Duration) (time.Time, error)
+	// GetDurationPS looks and get duration value by given path and separator.
+	GetDurationPS(path, separator string) (time.Duration, error)
 
 	// Dot-getters (the same as PS getters but with hardcoded dot (".") separator).
 
@@ -103,6 +155,31 @@ type Interface interface {
 	DotInt(path string) (int64, error)
 	// DotUint looks and get unsigned integer value by given path and "." separator.
 	DotUint(path string) (uint64, error)
+	// DotInt8 looks and get 8-bit integer value by given path and "." separator.
+	DotInt8(path string) (int8, error)
+	// DotInt16 looks and get 16-bit integer value by given path and "." separator.
+	DotInt16(path string) (int16, error)
+	// DotInt32 looks and get 32-bit integer value by given path and "." separator.
+	DotInt32(path string) (int32, error)
+	// DotUint8 looks and get 8-bit unsigned integer value by given path and "." separator.
+	DotUint8(path string) (uint8, error)
+	// DotUint16 looks and get 16-bit unsigned integer value by given path and "." separator.
+	DotUint16(path string) (uint16, error)
+	// DotUint32 looks and get 32-bit unsigned integer value by given path and "." separator.
+	DotUint32(path string) (uint32, error)
+	// DotBigInt looks and get arbitrary-precision integer value by given path and "." separator.
+	DotBigInt(path string) (*big.Int, error)
+	// DotBigFloat looks and get arbitrary-precision float value by given path and "." separator.
+	DotBigFloat(prec uint, path string) (*big.Float, error)
+	// DotDecimal looks and get lossless decimal value by given path and "." separator.
+	DotDecimal(path string) (Decimal, error)
+	// DotTime looks and get time value by given path and "." separator using default layouts
+	// (see Vector.SetTimeLayouts).
+	DotTime(path string) (time.Time, error)
+	// DotUnix looks and get time from numeric epoch value by given path and "." separator.
+	DotUnix(path string, unit time.Duration) (time.Time, error)
+	// DotDuration looks and get duration value by given path and "." separator.
+	DotDuration(path string) (time.Duration, error)
 
 	// KeepPtr guarantees that vector object wouldn't be collected by GC.
 	KeepPtr()
@@ -116,6 +193,12 @@ type Interface interface {
 	ErrorOffset() int
 	// Prealloc prepares space for further parse.
 	Prealloc(size uint)
+	// Stats returns lengths and capacities of internal arrays.
+	Stats() Stats
+	// Shrink resets the vector and trims capacities of internal arrays according policy.
+	Shrink(policy ShrinkPolicy)
+	// SetAdaptive enables adaptive pre-size of internal arrays.
+	SetAdaptive(a *Adaptive)
 	// Reset vector data.
 	Reset()
 }
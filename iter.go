@@ -0,0 +1,67 @@
+//go:build go1.23
+
+package vector
+
+import "iter"
+
+// Roots returns iterator over root nodes.
+func (vec *Vector) Roots() iter.Seq2[int, *Node] {
+	return func(yield func(int, *Node) bool) {
+		rootRow := vec.Index.GetRow(0)
+		for c, i := range rootRow {
+			if !yield(c, &vec.nodes[i]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns iterator over children nodes with their indexes.
+func (n *Node) All() iter.Seq2[int, *Node] {
+	return func(yield func(int, *Node) bool) {
+		idx := n.childrenIdx()
+		vec := n.indirectVector()
+		if len(idx) == 0 || vec == nil {
+			return
+		}
+		for c, i := range idx {
+			if !yield(c, &vec.nodes[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns iterator over keys of children nodes.
+func (n *Node) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, node := range n.All() {
+			if !yield(node.KeyString()) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns iterator over children nodes.
+func (n *Node) Values() iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		for _, node := range n.All() {
+			if !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// Descendants returns iterator over all descendants of the node in pre-order (see Walk).
+func (n *Node) Descendants() iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		n.Walk(PreOrder, func(path PathCursor, node *Node) WalkAction {
+			if path.Len() == 0 || yield(node) {
+				return Continue
+			}
+			return Stop
+		})
+	}
+}
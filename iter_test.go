@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package vector
+
+import "testing"
+
+func TestIter(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	testEmit(vec)
+
+	var c int
+	for i, node := range vec.Roots() {
+		if i != 0 || node.Type() != TypeObject {
+			t.Error("root mismatch")
+		}
+		c++
+	}
+	if c != 1 {
+		t.Errorf("roots count mismatch: need 1, got %d", c)
+	}
+
+	var keys string
+	for key := range vec.Root().Keys() {
+		keys += key
+	}
+	if keys != "ac" {
+		t.Errorf("keys mismatch: need 'ac', got '%s'", keys)
+	}
+	for i, node := range vec.Dot("c").All() {
+		if node.Index() != vec.Dot("c").At(i).Index() {
+			t.Errorf("child %d mismatch", i)
+		}
+	}
+
+	var types []Type
+	for node := range vec.Root().Descendants() {
+		if node.Type() == TypeNumber {
+			break
+		}
+		types = append(types, node.Type())
+	}
+	if len(types) != 3 || types[0] != TypeObject || types[1] != TypeString || types[2] != TypeArray {
+		t.Errorf("descendants mismatch: %v", types)
+	}
+	if len(vec.bufWK) != 0 {
+		t.Error("walk buffer must be released on break")
+	}
+}
@@ -0,0 +1,95 @@
+//go:build !vector_compact && !vector_large
+
+package vector
+
+import (
+	"math"
+
+	"github.com/koykov/bitset"
+)
+
+// Default memory layout of byteptr and node.
+const (
+	byteptrSize = 32
+	nodeSize    = 120
+	// Byteptr bits reserved by the layout.
+	bitsLayout = 0
+)
+
+// Type of byteptr's offset, length and capacity fields and its maximum value.
+type span = uint32
+
+const maxSpan = math.MaxUint32
+
+// Integer type of node's index fields.
+type nint = int
+
+type Byteptr struct {
+	addr, vptr uintptr
+
+	bits bitset.Bitset32
+
+	offset, len, cap span
+}
+
+// Node object.
+type Node struct {
+	// Node type.
+	typ Type
+	// Key/value byteptr objects.
+	key, val Byteptr
+	// Node index in array, depth in an index tree, offset in index row and limit of childs in index row.
+	idx, depth, offset, limit nint
+	// Index of parent node in array (-1 for root nodes).
+	pidx nint
+	// Raw pointer to vector.
+	// It's safe to use uintptr here because vector guaranteed to exist while the node is alive and isn't garbage
+	// collected.
+	vptr uintptr
+}
+
+// Bind node and its key/value to the vector.
+func (n *Node) bind(vptr uintptr) {
+	n.vptr = vptr
+	n.key.bind(vptr)
+	n.val.bind(vptr)
+}
+
+// Bind byteptr to the vector.
+func (p *Byteptr) bind(vptr uintptr) {
+	p.vptr = vptr
+}
+
+// Get raw pointer to the vector.
+func (p *Byteptr) vectorPtr() uintptr {
+	return p.vptr
+}
+
+// Get address of data base.
+func (p *Byteptr) baseAddr() uintptr {
+	return p.addr
+}
+
+// Set address of data base.
+func (p *Byteptr) setBaseAddr(addr uintptr) {
+	p.addr = addr
+}
+
+// Get capacity of data base.
+func (p *Byteptr) capacity() span {
+	return p.cap
+}
+
+// Set capacity of data base.
+func (p *Byteptr) setCap(cap span) {
+	p.cap = cap
+}
+
+// Copy data of src to p. Bound p keeps its vector, otherwise vector of src copies as well.
+func (p *Byteptr) copyFrom(src *Byteptr) {
+	vptr := p.vptr
+	*p = *src
+	if vptr != 0 {
+		p.vptr = vptr
+	}
+}
@@ -0,0 +1,187 @@
+//go:build vector_compact && !vector_large
+
+package vector
+
+import (
+	"math"
+
+	"github.com/koykov/bitset"
+	"github.com/koykov/indirect"
+)
+
+// Compact memory layout of byteptr and node.
+//
+// Byteptr bound to the vector stores offset of data base relative to the vector's source or buffer instead of address
+// and doesn't store capacity. Index fields of the node are 32-bit.
+const (
+	byteptrSize = 24
+	nodeSize    = 88
+	// Byteptr bits reserved by the layout.
+	bitsLayout = 1<<bitBound | 1<<bitBaseBuf
+)
+
+// Type of byteptr's offset, length and capacity fields and its maximum value.
+type span = uint32
+
+const maxSpan = math.MaxUint32
+
+// Base offset of byteptr that has no data.
+const baseNone = maxSpan
+
+// Integer type of node's index fields.
+type nint = int32
+
+type Byteptr struct {
+	// Raw pointer to vector if byteptr is bound (see bitBound), otherwise raw address of data base.
+	ptr uintptr
+
+	bits bitset.Bitset32
+
+	// Offset of data base in the vector's source or buffer (see bitBaseBuf). Buffered byteptr keeps here offset of the
+	// original data in the source.
+	base span
+
+	offset, len span
+}
+
+// Node object.
+type Node struct {
+	// Node type.
+	typ Type
+	// Key/value byteptr objects.
+	key, val Byteptr
+	// Node index in array, depth in an index tree, offset in index row and limit of childs in index row.
+	idx, depth, offset, limit nint
+	// Index of parent node in array (-1 for root nodes).
+	pidx nint
+	// Raw pointer to vector.
+	// It's safe to use uintptr here because vector guaranteed to exist while the node is alive and isn't garbage
+	// collected.
+	vptr uintptr
+}
+
+// Bind node and its key/value to the vector.
+func (n *Node) bind(vptr uintptr) {
+	n.vptr = vptr
+	n.key.bind(vptr)
+	n.val.bind(vptr)
+}
+
+// Bind byteptr to the vector.
+//
+// Data of bound byteptr keeps relative to the vector. Unbound data rebinds by its address, data outside the source and
+// buffer of the vector can't be bound and byteptr stays unbound.
+func (p *Byteptr) bind(vptr uintptr) {
+	if p.bits.CheckBit(bitBound) {
+		if vptr != 0 {
+			p.ptr = vptr
+			return
+		}
+		// Unbind: data address becomes raw.
+		addr := p.dataAddr()
+		p.bits.SetBit(bitBound, false)
+		p.bits.SetBit(bitBaseBuf, false)
+		p.bits.SetBit(bitBuffered, false)
+		p.ptr, p.base, p.offset = addr, 0, 0
+		return
+	}
+	if vptr == 0 {
+		return
+	}
+	addr := p.ptr
+	p.ptr, p.base = vptr, baseNone
+	p.bits.SetBit(bitBound, true)
+	p.setBaseAddr(addr)
+}
+
+// Get raw pointer to the vector.
+func (p *Byteptr) vectorPtr() uintptr {
+	if p.bits.CheckBit(bitBound) {
+		return p.ptr
+	}
+	return 0
+}
+
+// Get address of data base.
+//
+// Address of buffered byteptr is the original location of data in the source.
+func (p *Byteptr) baseAddr() uintptr {
+	if !p.bits.CheckBit(bitBound) {
+		return p.ptr
+	}
+	if p.base == baseNone {
+		return 0
+	}
+	vec := (*Vector)(indirect.ToUnsafePtr(p.ptr))
+	if p.bits.CheckBit(bitBaseBuf) {
+		return addrOf(vec.buf) + uintptr(p.base)
+	}
+	if addr, _ := vec.baseRegion(); addr != 0 {
+		return addr + uintptr(p.base)
+	}
+	return 0
+}
+
+// Set address of data base.
+//
+// Address outside the source and buffer unbinds byteptr from the vector.
+func (p *Byteptr) setBaseAddr(addr uintptr) {
+	if !p.bits.CheckBit(bitBound) {
+		p.ptr = addr
+		return
+	}
+	vec := (*Vector)(indirect.ToUnsafePtr(p.ptr))
+	p.base = baseNone
+	p.bits.SetBit(bitBaseBuf, false)
+	lo, n := vec.baseRegion()
+	buf := addrOf(vec.buf)
+	switch {
+	case addr == 0:
+	case lo != 0 && addr >= lo && addr-lo <= uintptr(n) && addr-lo < maxSpan:
+		p.base = span(addr - lo)
+	case p.bits.CheckBit(bitBuffered):
+		// Original location of buffered data is required only inside the source.
+	case buf != 0 && addr >= buf && addr-buf <= uintptr(len(vec.buf)) && addr-buf < maxSpan:
+		p.base = span(addr - buf)
+		p.bits.SetBit(bitBaseBuf, true)
+	default:
+		p.bits.SetBit(bitBound, false)
+		p.ptr = addr
+	}
+}
+
+// Get capacity of data base. Isn't stored by compact layout.
+func (p *Byteptr) capacity() span {
+	return p.len
+}
+
+// Set capacity of data base. Isn't stored by compact layout.
+func (p *Byteptr) setCap(_ span) {}
+
+// Copy data of src to p. Bound p keeps its vector, otherwise vector of src copies as well.
+func (p *Byteptr) copyFrom(src *Byteptr) {
+	vptr := p.vectorPtr()
+	*p = *src
+	if vptr == 0 || vptr == src.vectorPtr() {
+		return
+	}
+	// Data of another vector rebinds by address.
+	addr := src.baseAddr()
+	if src.bits.CheckBit(bitBuffered) {
+		addr, p.offset = src.dataAddr(), 0
+		p.bits.SetBit(bitBuffered, false)
+	}
+	p.ptr = vptr
+	p.bits.SetBit(bitBound, true)
+	p.setBaseAddr(addr)
+}
+
+// Get memory region that data base of bound byteptr is relative to: the source or the whole blob of memory mapped
+// snapshot (see OpenSnapshot).
+func (vec *Vector) baseRegion() (uintptr, int) {
+	if vec.mm != nil {
+		b := vec.mm.blobBytes()
+		return addrOf(b), len(b)
+	}
+	return vec.addr, len(vec.src)
+}
@@ -0,0 +1,91 @@
+package vector
+
+// LazyHelper is an optional extension of Helper that allows parsing children of deferred nodes on demand.
+//
+// In lazy mode (see FlagLazy) parsers record container nodes only as raw source spans (node value) and mark them using
+// Node.Defer. Children of such nodes will be parsed on first access via Get, Each, Children, ... methods.
+type LazyHelper interface {
+	// Expand parses raw span of the node (see Node.Value) and registers its children using node.AcquireChild* methods.
+	// Nested containers may be deferred as well.
+	Expand(vec *Vector, node *Node) error
+}
+
+// Defer marks container node as deferred.
+//
+// Value of the node must contain raw source span of the container.
+func (n *Node) Defer() *Node {
+	n.val.SetBit(bitDeferred, true)
+	return n
+}
+
+// Deferred checks if children of the node aren't parsed (or decoded from mapped snapshot) yet.
+func (n *Node) Deferred() bool {
+	return n.val.CheckBit(bitDeferred) || n.val.CheckBit(bitMapped)
+}
+
+// Expand parses children of deferred node in place.
+//
+// Children will be appended to the end of nodes array and index row. Does nothing if node isn't deferred. Expanding
+// after savepoint may be undone using Vector.Rollback.
+// Children of the node loaded from memory mapped snapshot decodes from the mapped region (see Vector.OpenSnapshot).
+func (n *Node) Expand() error {
+	if !n.Deferred() {
+		return nil
+	}
+	vec := n.indirectVector()
+	if vec == nil {
+		return ErrInternal
+	}
+	vec.saveNode(n)
+	if n.val.CheckBit(bitMapped) {
+		if vec.mm == nil {
+			return ErrInternal
+		}
+		err := vec.mm.expand(vec, n)
+		vec.ReleaseNode(int(n.idx), n)
+		return err
+	}
+	h, ok := vec.Helper.(LazyHelper)
+	if !ok {
+		return ErrNoHelper
+	}
+	n.val.SetBit(bitDeferred, false)
+	off := vec.Index.Len(int(n.depth) + 1)
+	n.SetOffset(off).SetLimit(off)
+	err := h.Expand(vec, n)
+	vec.ReleaseNode(int(n.idx), n)
+	return err
+}
+
+// Implicit version of Expand.
+//
+// Uses by getters and iterators. Node failed to expand marks as invalid: it gets unknown type and loses children, error
+// offset of the vector sets to the node position in the source (see Vector.ErrorOffset).
+func (n *Node) expand() {
+	if !n.Deferred() {
+		return
+	}
+	if err := n.Expand(); err != nil {
+		n.invalidate()
+	}
+}
+
+// Mark node as invalid.
+func (n *Node) invalidate() {
+	vec := n.indirectVector()
+	if vec == nil {
+		return
+	}
+	n.typ = TypeUnknown
+	n.val.SetBit(bitDeferred, false)
+	n.val.SetBit(bitMapped, false)
+	// Children registered before the error are left in the index row, but don't belong to the node anymore.
+	off := vec.Index.Len(int(n.depth) + 1)
+	n.SetOffset(off).SetLimit(off)
+	vec.ReleaseNode(int(n.idx), n)
+	lo, _ := n.SourceSpan()
+	if lo < 0 {
+		lo = 0
+	}
+	vec.SetErrOffset(lo)
+}
@@ -0,0 +1,64 @@
+package vector
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// Helper that expands comma-separated list of numbers.
+type testLazyHelper struct{}
+
+func (testLazyHelper) Indirect(p *Byteptr) []byte          { return p.RawBytes() }
+func (testLazyHelper) Beautify(_ io.Writer, _ *Node) error { return ErrNotImplement }
+func (testLazyHelper) Marshal(_ io.Writer, _ *Node) error  { return ErrNotImplement }
+
+func (testLazyHelper) Expand(vec *Vector, node *Node) error {
+	span := node.Value()
+	raw, off := span.RawBytes(), span.Offset()
+	for len(raw) > 0 {
+		i := bytes.IndexByte(raw, ',')
+		if i < 0 {
+			i = len(raw)
+		}
+		child, j := vec.AcquireChildWithType(node, node.Depth()+1, TypeNumber)
+		child.Value().Init(vec.Src(), off, i)
+		vec.ReleaseNode(j, child)
+		if i == len(raw) {
+			break
+		}
+		raw, off = raw[i+1:], off+i+1
+	}
+	return nil
+}
+
+func TestLazy(t *testing.T) {
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+
+	vec.SetHelper(testLazyHelper{})
+	defer vec.SetHelper(nil)
+	_ = vec.SetSrc([]byte("[1,22,333]"), false)
+	root, _ := vec.AcquireNodeWithType(0, TypeArray)
+	root.Value().Init(vec.Src(), 1, 8)
+	root.Defer()
+	if vec.Len() != 1 {
+		t.Fatalf("children must be deferred, got %d nodes", vec.Len())
+	}
+
+	if s := vec.GetString("1"); s != "" {
+		t.Errorf("unexpected string %s", s)
+	}
+	if i, _ := vec.GetInt("2"); i != 333 {
+		t.Errorf("value mismatch: need 333, got %d", i)
+	}
+	if root.Deferred() || vec.Len() != 4 {
+		t.Errorf("node must be expanded once, got %d nodes", vec.Len())
+	}
+	var c int
+	root.Each(func(_ int, _ *Node) { c++ })
+	if c != 3 {
+		t.Errorf("children count mismatch: need 3, got %d", c)
+	}
+}
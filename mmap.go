@@ -0,0 +1,250 @@
+package vector
+
+import (
+	"encoding/binary"
+
+	"github.com/koykov/bitset"
+)
+
+// Memory mapped snapshot.
+//
+// Nodes and index rows decode from the mapped region on demand, source bytes points directly to the mapped region.
+type mapping struct {
+	// Mapped file contents.
+	data []byte
+	// Snapshot header.
+	h snapshotHeader
+	// Offsets of blob and node records in data.
+	blob, nodes uint64
+	// Offsets and lengths of index rows in data.
+	rows []mappedRow
+	// Total length of index rows.
+	rowsL uint64
+	// Bitsets of claimed index rows positions and child records (see claim).
+	pos, refs []uint64
+}
+
+type mappedRow struct {
+	// Offset in data, length and position of the first item in flat list of all rows.
+	off, len, base uint64
+}
+
+// OpenSnapshot maps snapshot file written using WriteSnapshot to memory and loads read-only vector from it.
+//
+// In opposite to ReadSnapshot file contents isn't read to the heap. Source bytes points to the mapped region and nodes
+// decodes from it lazily on first access (only root nodes decodes at once). The mapping is read-only, thus flag
+// FlagCopyUnescape sets and unescaped values are written to the vector's buffer. Truncated or corrupt file produces an
+// error, including the case of lazy decoding (see Node.Expand).
+//
+// The mapping releases on vector reset, so nodes mustn't be used after that.
+func (vec *Vector) OpenSnapshot(path string) error {
+	vec.Reset()
+	data, err := mmapFile(path)
+	if err != nil {
+		return err
+	}
+	m := &mapping{data: data}
+	if err = m.init(); err != nil {
+		_ = munmap(data)
+		return err
+	}
+	vec.mm = m
+	b := m.blobBytes()
+	vec.src = b[m.h.srcOff : m.h.srcOff+m.h.srcLen]
+	vec.addr = addrOf(vec.src)
+	vec.selfPtr = vec.ptr()
+
+	if len(m.rows) > 0 {
+		for i := uint64(0); i < m.rows[0].len; i++ {
+			k, err := m.row(0, i)
+			if err != nil {
+				vec.Reset()
+				return err
+			}
+			node, _ := vec.AcquireNode(0)
+			if err = m.decode(node, k, 0); err != nil {
+				vec.Reset()
+				return err
+			}
+		}
+	}
+	vec.errOff = int(m.h.errOff)
+	vec.Bitset = bitset.Bitset(m.h.flags)
+	vec.setHelperFlags()
+	vec.SetBit(FlagCopyUnescape, true)
+	return nil
+}
+
+// Release the mapping.
+func (vec *Vector) closeSnapshot() {
+	if vec.mm == nil {
+		return
+	}
+	_ = munmap(vec.mm.data)
+	vec.mm = nil
+	vec.src, vec.addr = nil, 0
+}
+
+// Check the mapped data and calculate offsets of the parts.
+func (m *mapping) init() error {
+	n := uint64(len(m.data))
+	if err := m.h.decode(m.data); err != nil {
+		return err
+	}
+	m.blob = snapshotHeaderSize
+	if m.h.blobLen > n-m.blob {
+		return ErrSnapshotCorrupt
+	}
+	m.nodes = m.blob + m.h.blobLen
+	if m.h.nodeL > (n-m.nodes)/snapshotNodeSize {
+		return ErrSnapshotCorrupt
+	}
+	off := m.nodes + m.h.nodeL*snapshotNodeSize
+	for i := uint64(0); i < m.h.rows; i++ {
+		if n-off < 8 {
+			return ErrSnapshotCorrupt
+		}
+		l := binary.LittleEndian.Uint64(m.data[off:])
+		off += 8
+		if l > m.h.nodeL || l > (n-off)/4 {
+			return ErrSnapshotCorrupt
+		}
+		m.rows = append(m.rows, mappedRow{off: off, len: l, base: m.rowsL})
+		m.rowsL += l
+		off += l * 4
+	}
+	if len(m.rows) > 0 {
+		// Roots can't be children of any record.
+		return m.claim(0, 0, m.rows[0].len, false)
+	}
+	return nil
+}
+
+// Get blob bytes.
+func (m *mapping) blobBytes() []byte {
+	return m.data[m.blob : m.blob+m.h.blobLen]
+}
+
+// Get node index at position i of the row on given depth.
+func (m *mapping) row(depth int, i uint64) (uint64, error) {
+	if depth >= len(m.rows) || i >= m.rows[depth].len {
+		return 0, ErrSnapshotCorrupt
+	}
+	k := uint64(binary.LittleEndian.Uint32(m.data[m.rows[depth].off+i*4:]))
+	if k >= m.h.nodeL {
+		return 0, ErrSnapshotCorrupt
+	}
+	return k, nil
+}
+
+// Decode node record k to node.
+//
+// Containers marks as mapped and keep record index in offset until expanding.
+func (m *mapping) decode(node *Node, k uint64, depth int) error {
+	rec := m.data[m.nodes+k*snapshotNodeSize:]
+	// Indices of mapped nodes differ from records, thus keep the parent set on acquiring.
+	pidx := node.pidx
+	if err := decodeNode(node, rec, addrOf(m.blobBytes()), m.h.blobLen); err != nil {
+		return err
+	}
+	if int(node.depth) != depth {
+		return ErrSnapshotCorrupt
+	}
+	node.pidx = pidx
+	node.offset, node.limit = 0, 0
+	if (node.typ == TypeObject || node.typ == TypeArray || node.typ == TypeAlias) && !node.val.CheckBit(bitDeferred) {
+		node.offset = nint(k)
+		node.val.SetBit(bitMapped, true)
+	}
+	return nil
+}
+
+// Decode children of mapped node n.
+func (m *mapping) expand(vec *Vector, n *Node) error {
+	k := uint64(n.offset)
+	if k >= m.h.nodeL {
+		return ErrSnapshotCorrupt
+	}
+	rec := m.data[m.nodes+k*snapshotNodeSize:]
+	lo := uint64(binary.LittleEndian.Uint32(rec[8:]))
+	hi := uint64(binary.LittleEndian.Uint32(rec[12:]))
+	if hi == 0 {
+		hi = lo
+	}
+	if hi < lo {
+		return ErrSnapshotCorrupt
+	}
+	if err := m.claim(int(n.depth)+1, lo, hi, n.typ == TypeAlias); err != nil {
+		return err
+	}
+
+	n.val.SetBit(bitMapped, false)
+	off := vec.Index.Len(int(n.depth) + 1)
+	n.SetOffset(off).SetLimit(off)
+	for i := lo; i < hi; i++ {
+		c, err := m.row(int(n.depth)+1, i)
+		if err != nil {
+			return err
+		}
+		child, j := vec.AcquireChild(n, int(n.depth)+1)
+		if err = m.decode(child, c, int(n.depth)+1); err != nil {
+			return err
+		}
+		vec.ReleaseNode(j, child)
+	}
+	return nil
+}
+
+// Decode all mapped nodes.
+//
+// Uses before operations that works with raw nodes array (see CloneTo, WriteSnapshot).
+func (vec *Vector) decodeAll() error {
+	if vec.mm == nil {
+		return nil
+	}
+	// Decoded children appends to the end of nodes array, thus they will be decoded as well.
+	for i := 0; i < vec.nodeL; i++ {
+		if node := &vec.nodes[i]; node.val.CheckBit(bitMapped) {
+			if err := node.Expand(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Claim children range [lo, hi) of the record in index row on given depth.
+//
+// Range mustn't exceed the row and overlap ranges claimed before, each record may be a child of only one container
+// (except children of aliases). Otherwise, shared subtrees may be decoded many times.
+func (m *mapping) claim(depth int, lo, hi uint64, alias bool) error {
+	if hi == lo {
+		return nil
+	}
+	if depth >= len(m.rows) || hi > m.rows[depth].len {
+		return ErrSnapshotCorrupt
+	}
+	if m.pos == nil {
+		m.pos = make([]uint64, (m.rowsL+63)/64)
+		m.refs = make([]uint64, (m.h.nodeL+63)/64)
+	}
+	for i := lo; i < hi; i++ {
+		p := m.rows[depth].base + i
+		if m.pos[p/64]&(1<<(p%64)) != 0 {
+			return ErrSnapshotCorrupt
+		}
+		m.pos[p/64] |= 1 << (p % 64)
+		if alias {
+			continue
+		}
+		c, err := m.row(depth, i)
+		if err != nil {
+			return err
+		}
+		if m.refs[c/64]&(1<<(c%64)) != 0 {
+			return ErrSnapshotCorrupt
+		}
+		m.refs[c/64] |= 1 << (c % 64)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package vector
+
+func mmapFile(_ string) ([]byte, error) {
+	return nil, ErrNotImplement
+}
+
+func munmap(_ []byte) error {
+	return ErrNotImplement
+}
@@ -0,0 +1,108 @@
+//go:build unix
+
+package vector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenSnapshot(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	vec, cpy := testPool.Get().(*Vector), testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer testPool.Put(cpy)
+	defer vec.Reset()
+	defer cpy.Reset()
+	vec.Reset()
+
+	_ = vec.SetSrc([]byte(src), false)
+	testEmit(vec)
+	var buf bytes.Buffer
+	if err := vec.WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snap := buf.Bytes()
+	path := filepath.Join(t.TempDir(), "vec.snap")
+	if err := os.WriteFile(path, snap, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cpy.OpenSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if !cpy.Root().Deferred() {
+		t.Error("root must be mapped")
+	}
+	if !cpy.CheckBit(FlagCopyUnescape) {
+		t.Error("read-only mapping requires copy unescape")
+	}
+	if s := cpy.DotString("a.b"); s != "foo" {
+		t.Errorf("value mismatch: need 'foo', got '%s'", s)
+	}
+	if i, _ := cpy.DotInt("c.1"); i != 2 {
+		t.Errorf("value mismatch: need 2, got %d", i)
+	}
+	if !cpy.EqualWith(vec) || string(cpy.Src()) != src {
+		t.Error("vectors mismatch")
+	}
+	cpy.Reset()
+
+	t.Run("ranges", func(t *testing.T) {
+		var h snapshotHeader
+		_ = h.decode(snap)
+		nodes := snapshotHeaderSize + int(h.blobLen)
+		a, c := vec.Dot("a"), vec.Dot("c")
+		rec := func(b []byte, k int) []byte {
+			return b[nodes+k*snapshotNodeSize:]
+		}
+		// Children range of "c" overlaps range of "a".
+		bad := append([]byte(nil), snap...)
+		binary.LittleEndian.PutUint32(rec(bad, c.Index())[8:], uint32(a.offset))
+		// Child of "c" refers to record of "a.b".
+		bad1 := append([]byte(nil), snap...)
+		rows := nodes + vec.Len()*snapshotNodeSize
+		rows += 8 + 4*vec.Index.Len(0) + 8 + 4*vec.Index.Len(1) + 8
+		binary.LittleEndian.PutUint32(bad1[rows+4*int(c.offset):], uint32(vec.Dot("a.b").Index()))
+		for i, b := range [][]byte{bad, bad1} {
+			if err := os.WriteFile(path, b, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := cpy.OpenSnapshot(path); err != nil {
+				t.Fatal(err)
+			}
+			if err := cpy.WriteSnapshot(io.Discard); err != ErrSnapshotCorrupt {
+				t.Errorf("corrupt ranges #%d must fail, got %v", i, err)
+			}
+			cpy.Reset()
+			// Lazy access marks corrupt node as invalid.
+			if err := cpy.OpenSnapshot(path); err != nil {
+				t.Fatal(err)
+			}
+			if s := cpy.DotString("a.b"); s != "foo" {
+				t.Errorf("value mismatch: need 'foo', got '%s'", s)
+			}
+			_ = cpy.Dot("c.0")
+			if c := cpy.Dot("c"); c.Type() != TypeUnknown || c.Limit() != 0 || cpy.ErrorOffset() != 18 {
+				t.Errorf("corrupt ranges #%d must invalidate node, got %d/%d/%d", i, c.Type(), c.Limit(),
+					cpy.ErrorOffset())
+			}
+			cpy.Reset()
+		}
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		for _, l := range []int{0, 10, 100, len(snap) - 1} {
+			if err := os.WriteFile(path, snap[:l], 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := cpy.OpenSnapshot(path); err != ErrSnapshotCorrupt {
+				t.Errorf("truncated snapshot (%d) must fail, got %v", l, err)
+			}
+		}
+	})
+}
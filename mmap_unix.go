@@ -0,0 +1,36 @@
+//go:build unix
+
+package vector
+
+import (
+	"os"
+	"syscall"
+)
+
+// Map file to memory.
+//
+// The mapping is read-only, thus unescape of source must write to vector's buffer (see FlagCopyUnescape).
+func mmapFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := fi.Size()
+	if size < snapshotHeaderSize {
+		return nil, ErrSnapshotCorrupt
+	}
+	if int64(int(size)) != size {
+		return nil, ErrSnapshotCorrupt
+	}
+	return syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_PRIVATE)
+}
+
+// Unmap memory region.
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
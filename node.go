@@ -1,10 +1,7 @@
 package vector
 
 import (
-	"bytes"
 	"io"
-	"strconv"
-	"unsafe"
 
 	"github.com/koykov/entry"
 	"github.com/koykov/indirect"
@@ -25,22 +22,6 @@ const (
 	TypeAlias
 )
 
-const nodeSize = 120
-
-// Node object.
-type Node struct {
-	// Node type.
-	typ Type
-	// Key/value byteptr objects.
-	key, val Byteptr
-	// Node index in array, depth in an index tree, offset in index row and limit of childs in index row.
-	idx, depth, offset, limit int
-	// Raw pointers to vector and parent node.
-	// It's safe to use uintptr here because vector guaranteed to exist while the node is alive and isn't garbage
-	// collected.
-	vptr, pptr uintptr
-}
-
 // Null node instance. Will return for empty results.
 var nullNode = &Node{typ: TypeNull}
 
@@ -56,35 +37,35 @@ func (n *Node) Type() Type {
 
 // Index returns node index in the array of nodes.
 func (n *Node) Index() int {
-	return n.idx
+	return int(n.idx)
 }
 
 // Depth returns node depth in index matrix.
 func (n *Node) Depth() int {
-	return n.depth
+	return int(n.depth)
 }
 
 // SetOffset sets offset in the index row.
 func (n *Node) SetOffset(offset int) *Node {
-	n.offset = offset
+	n.offset = nint(offset)
 	return n
 }
 
 // Offset returns offset of childs in the index row.
 func (n *Node) Offset() int {
-	return n.offset
+	return int(n.offset)
 }
 
 // SetLimit sets limit of childs in index row.
 func (n *Node) SetLimit(limit int) *Node {
-	n.limit = limit
+	n.limit = nint(limit)
 	return n
 }
 
 // Limit returns limit of childs in index row.
 func (n *Node) Limit() int {
 	if r := n.limit - n.offset; r >= 0 {
-		return r
+		return int(r)
 	}
 	return 0
 }
@@ -98,8 +79,9 @@ func (n *Node) Exists(key string) bool {
 	if vec == nil {
 		return false
 	}
-	for i := n.offset; i < n.limit; i++ {
-		k := vec.Index.val(n.depth+1, i)
+	n.expand()
+	for i := int(n.offset); i < int(n.limit); i++ {
+		k := vec.Index.val(int(n.depth)+1, i)
 		c := &vec.nodes[k]
 		if c.key.String() == key {
 			return true
@@ -181,55 +163,101 @@ func (n *Node) ForceString() string {
 	return n.val.String()
 }
 
-// Bool returns value as boolean.
-func (n *Node) Bool() bool {
-	if n.typ != TypeBool {
-		return false
+// Float returns value as float number.
+//
+// Value parses from unescaped bytes using built-in parser, extra syntax may be enabled using flags FlagNumUnderscore,
+// FlagNumPrefix and FlagNumInfNaN (see Vector.SetBit).
+func (n *Node) Float() (float64, error) {
+	if n.typ != TypeNumber {
+		return 0, ErrIncompatType
 	}
-	lower := toLowerASCII(n.val.RawBytes())
-	if bytes.Equal(lower, bTrue) {
-		return true
+	return parseFloat(n.val.Bytes(), 64, n.numOpts())
+}
+
+// Int returns value as integer.
+//
+// Returns ErrOverflow if value doesn't fit int64. Exactly integral floats (eg 1e3) are allowed.
+func (n *Node) Int() (int64, error) {
+	if n.typ != TypeNumber {
+		return 0, ErrIncompatType
 	}
-	if n.val.bits.CheckBit(FlagExtraBool) {
-		return bytes.Equal(lower, bOn)
+	return parseInt(n.val.Bytes(), 64, n.numOpts())
+}
+
+// Uint returns value as unsigned integer.
+//
+// Returns ErrOverflow if value doesn't fit uint64. Exactly integral floats (eg 1e3) are allowed.
+func (n *Node) Uint() (uint64, error) {
+	if n.typ != TypeNumber {
+		return 0, ErrIncompatType
 	}
-	return false
+	return parseUint(n.val.Bytes(), 64, n.numOpts())
 }
 
-// Float returns value as float number.
-func (n *Node) Float() (float64, error) {
+// Int8 returns value as 8-bit integer.
+//
+// Returns ErrOverflow if value doesn't fit int8. Exactly integral floats (eg 3.0) are allowed.
+func (n *Node) Int8() (int8, error) {
 	if n.typ != TypeNumber {
 		return 0, ErrIncompatType
 	}
-	f, err := strconv.ParseFloat(n.val.RawString(), 64)
-	if err != nil {
-		return 0, err
+	x, err := parseInt(n.val.Bytes(), 8, n.numOpts())
+	return int8(x), err
+}
+
+// Int16 returns value as 16-bit integer.
+//
+// Returns ErrOverflow if value doesn't fit int16. Exactly integral floats (eg 3.0) are allowed.
+func (n *Node) Int16() (int16, error) {
+	if n.typ != TypeNumber {
+		return 0, ErrIncompatType
 	}
-	return f, nil
+	x, err := parseInt(n.val.Bytes(), 16, n.numOpts())
+	return int16(x), err
 }
 
-// Int returns value as integer.
-func (n *Node) Int() (int64, error) {
+// Int32 returns value as 32-bit integer.
+//
+// Returns ErrOverflow if value doesn't fit int32. Exactly integral floats (eg 3.0) are allowed.
+func (n *Node) Int32() (int32, error) {
 	if n.typ != TypeNumber {
 		return 0, ErrIncompatType
 	}
-	i, err := strconv.ParseInt(n.val.RawString(), 10, 64)
-	if err != nil {
-		return 0, err
+	x, err := parseInt(n.val.Bytes(), 32, n.numOpts())
+	return int32(x), err
+}
+
+// Uint8 returns value as 8-bit unsigned integer.
+//
+// Returns ErrOverflow if value doesn't fit uint8. Exactly integral floats (eg 3.0) are allowed.
+func (n *Node) Uint8() (uint8, error) {
+	if n.typ != TypeNumber {
+		return 0, ErrIncompatType
 	}
-	return i, nil
+	x, err := parseUint(n.val.Bytes(), 8, n.numOpts())
+	return uint8(x), err
 }
 
-// Uint returns value as unsigned integer.
-func (n *Node) Uint() (uint64, error) {
+// Uint16 returns value as 16-bit unsigned integer.
+//
+// Returns ErrOverflow if value doesn't fit uint16. Exactly integral floats (eg 3.0) are allowed.
+func (n *Node) Uint16() (uint16, error) {
 	if n.typ != TypeNumber {
 		return 0, ErrIncompatType
 	}
-	u, err := strconv.ParseUint(n.val.RawString(), 10, 64)
-	if err != nil {
-		return 0, err
+	x, err := parseUint(n.val.Bytes(), 16, n.numOpts())
+	return uint16(x), err
+}
+
+// Uint32 returns value as 32-bit unsigned integer.
+//
+// Returns ErrOverflow if value doesn't fit uint32. Exactly integral floats (eg 3.0) are allowed.
+func (n *Node) Uint32() (uint32, error) {
+	if n.typ != TypeNumber {
+		return 0, ErrIncompatType
 	}
-	return u, nil
+	x, err := parseUint(n.val.Bytes(), 32, n.numOpts())
+	return uint32(x), err
 }
 
 // Each applies custom function to each child of the node.
@@ -317,18 +345,19 @@ func (n *Node) Reset() *Node {
 	n.key.Reset()
 	n.val.Reset()
 	n.depth, n.offset, n.limit = 0, 0, 0
-	n.vptr, n.pptr = 0, 0
+	n.pidx, n.vptr = -1, 0
 	return n
 }
 
 // Get list of children indexes.
 func (n *Node) childrenIdx() []int {
 	if vec := n.indirectVector(); vec != nil {
+		n.expand()
 		var limit = n.limit
 		if limit == 0 {
 			limit = n.offset + 1
 		}
-		return vec.Index.get(n.depth+1, n.offset, limit)
+		return vec.Index.get(int(n.depth)+1, int(n.offset), int(limit))
 	}
 	return nil
 }
@@ -399,9 +428,14 @@ func (n *Node) Sort() *Node {
 func (n *Node) SwapWith(node *Node) {
 	if vec := n.indirectVector(); vec != nil {
 		i, j := n.idx, node.idx
-		if i < vec.nodeL && j < vec.nodeL {
+		if int(i) < vec.nodeL && int(j) < vec.nodeL {
+			// Parents keep by positions in the index, but children must follow the moved nodes.
+			pi, pj := vec.nodes[i].pidx, vec.nodes[j].pidx
 			vec.nodes[i].idx, vec.nodes[j].idx = j, i
 			vec.nodes[i], vec.nodes[j] = vec.nodes[j], vec.nodes[i]
+			vec.nodes[i].pidx, vec.nodes[j].pidx = pi, pj
+			vec.nodes[i].adopt(vec)
+			vec.nodes[j].adopt(vec)
 		}
 	}
 }
@@ -412,7 +446,7 @@ func (n *Node) AliasOf(node *Node) *Node {
 		return nullNode
 	}
 	if vec := n.indirectVector(); vec != nil {
-		n.SetLimit(vec.Index.Register(n.depth+1, node.Index()))
+		n.SetLimit(vec.Index.Register(int(n.depth)+1, node.Index()))
 		return n
 	}
 	return nullNode
@@ -493,11 +527,6 @@ func (n *Node) keyEqualKE(path string, e entry.Entry64) bool {
 	return n.typ != TypeAttribute && n.key.String() == skey
 }
 
-// Return self pointer of the node.
-func (n *Node) ptr() uintptr {
-	return uintptr(unsafe.Pointer(n))
-}
-
 // Restore the entire vector object from the unsafe pointer.
 //
 // This needs to reduce pointers count and avoids redundant GC checks.
@@ -508,19 +537,7 @@ func (n *Node) indirectVector() *Vector {
 	return (*Vector)(indirect.ToUnsafePtr(n.vptr))
 }
 
-// Restore the entire node object from the unsafe pointer.
-//
-// This needs to reduce pointers count and avoids redundant GC checks.
-func (n *Node) indirectNode() *Node {
-	if n.pptr == 0 {
-		return nil
-	}
-	return (*Node)(indirect.ToUnsafePtr(n.pptr))
-}
-
 var (
-	bTrue = []byte("true")
-	bOn   = []byte("on")
-
-	_ = nullNode.indirectNode
+	bOne  = []byte("1")
+	bZero = []byte("0")
 )
@@ -1,5 +1,10 @@
 package vector
 
+import (
+	"math/big"
+	"time"
+)
+
 // Shorthands for node.Get*PS() methods with "." used as separator.
 
 // Dot looks and get child node by given path and "." separator.
@@ -46,3 +51,63 @@ func (n *Node) DotInt(path string) (int64, error) {
 func (n *Node) DotUint(path string) (uint64, error) {
 	return n.GetUintPS(path, ".")
 }
+
+// DotInt8 looks and get child 8-bit integer by given path and "." separator.
+func (n *Node) DotInt8(path string) (int8, error) {
+	return n.GetInt8PS(path, ".")
+}
+
+// DotInt16 looks and get child 16-bit integer by given path and "." separator.
+func (n *Node) DotInt16(path string) (int16, error) {
+	return n.GetInt16PS(path, ".")
+}
+
+// DotInt32 looks and get child 32-bit integer by given path and "." separator.
+func (n *Node) DotInt32(path string) (int32, error) {
+	return n.GetInt32PS(path, ".")
+}
+
+// DotUint8 looks and get child 8-bit unsigned integer by given path and "." separator.
+func (n *Node) DotUint8(path string) (uint8, error) {
+	return n.GetUint8PS(path, ".")
+}
+
+// DotUint16 looks and get child 16-bit unsigned integer by given path and "." separator.
+func (n *Node) DotUint16(path string) (uint16, error) {
+	return n.GetUint16PS(path, ".")
+}
+
+// DotUint32 looks and get child 32-bit unsigned integer by given path and "." separator.
+func (n *Node) DotUint32(path string) (uint32, error) {
+	return n.GetUint32PS(path, ".")
+}
+
+// DotBigInt looks and get child arbitrary-precision integer by given path and "." separator.
+func (n *Node) DotBigInt(path string) (*big.Int, error) {
+	return n.GetBigIntPS(path, ".")
+}
+
+// DotBigFloat looks and get child arbitrary-precision float by given path and "." separator.
+func (n *Node) DotBigFloat(prec uint, path string) (*big.Float, error) {
+	return n.GetBigFloatPS(prec, path, ".")
+}
+
+// DotDecimal looks and get child lossless decimal by given path and "." separator.
+func (n *Node) DotDecimal(path string) (Decimal, error) {
+	return n.GetDecimalPS(path, ".")
+}
+
+// DotTime looks and get child time by given path and "." separator using default layouts (see Vector.SetTimeLayouts).
+func (n *Node) DotTime(path string) (time.Time, error) {
+	return n.GetTimePS(path, ".")
+}
+
+// DotUnix looks and get child time from numeric epoch by given path and "." separator.
+func (n *Node) DotUnix(path string, unit time.Duration) (time.Time, error) {
+	return n.GetUnixPS(path, ".", unit)
+}
+
+// DotDuration looks and get child duration by given path and "." separator.
+func (n *Node) DotDuration(path string) (time.Duration, error) {
+	return n.GetDurationPS(path, ".")
+}
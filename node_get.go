@@ -1,7 +1,9 @@
 package vector
 
 import (
+	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/koykov/entry"
 )
@@ -18,6 +20,7 @@ func (n *Node) Get(keys ...string) *Node {
 	if vec = n.indirectVector(); vec == nil {
 		return n
 	}
+	n.expand()
 	node := n
 	if n.typ == TypeAlias {
 		if idxs := n.ChildrenIndices(); len(idxs) > 0 {
@@ -25,8 +28,8 @@ func (n *Node) Get(keys ...string) *Node {
 		}
 	}
 	if node.typ == TypeObject {
-		for i := node.offset; i < node.limit; i++ {
-			idx := vec.Index.val(node.depth+1, i)
+		for i := int(node.offset); i < int(node.limit); i++ {
+			idx := vec.Index.val(int(node.depth)+1, i)
 			child := &vec.nodes[idx]
 			if child.keyEqual(keys[0]) {
 				tail := keys[1:]
@@ -40,10 +43,10 @@ func (n *Node) Get(keys ...string) *Node {
 	}
 	if node.typ == TypeArray {
 		i, err := strconv.Atoi(keys[0])
-		if err != nil || i >= node.limit {
+		if err != nil || i >= int(node.limit) {
 			return nullNode
 		}
-		idx := vec.Index.val(node.depth+1, node.offset+i)
+		idx := vec.Index.val(int(node.depth)+1, int(node.offset)+i)
 		child := &vec.nodes[idx]
 		tail := keys[1:]
 		if len(tail) == 0 {
@@ -69,6 +72,7 @@ func (n *Node) getKE(path string, keys ...entry.Entry64) *Node {
 	if vec = n.indirectVector(); vec == nil {
 		return n
 	}
+	n.expand()
 	node := n
 	if n.typ == TypeAlias {
 		if idxs := n.ChildrenIndices(); len(idxs) > 0 {
@@ -76,8 +80,8 @@ func (n *Node) getKE(path string, keys ...entry.Entry64) *Node {
 		}
 	}
 	if node.typ == TypeObject {
-		for i := node.offset; i < node.limit; i++ {
-			idx := vec.Index.val(node.depth+1, i)
+		for i := int(node.offset); i < int(node.limit); i++ {
+			idx := vec.Index.val(int(node.depth)+1, i)
 			child := &vec.nodes[idx]
 			if child.keyEqualKE(path, keys[0]) {
 				tail := keys[1:]
@@ -93,10 +97,10 @@ func (n *Node) getKE(path string, keys ...entry.Entry64) *Node {
 		lo, hi := keys[0].Decode()
 		skey := path[lo:hi]
 		i, err := strconv.Atoi(skey)
-		if err != nil || i >= node.limit {
+		if err != nil || i >= int(node.limit) {
 			return nullNode
 		}
-		idx := vec.Index.val(node.depth+1, node.offset+i)
+		idx := vec.Index.val(int(node.depth)+1, int(node.offset)+i)
 		child := &vec.nodes[idx]
 		tail := keys[1:]
 		if len(tail) == 0 {
@@ -148,11 +152,7 @@ func (n *Node) GetString(keys ...string) string {
 
 // GetBool looks and get child bool by given keys.
 func (n *Node) GetBool(keys ...string) bool {
-	node := n.Get(keys...)
-	if node.Type() != TypeBool {
-		return false
-	}
-	return node.Bool()
+	return n.Get(keys...).Bool()
 }
 
 // GetFloat looks and get child float by given keys.
@@ -191,6 +191,114 @@ func (n *Node) GetUint(keys ...string) (uint64, error) {
 	return node.Uint()
 }
 
+// GetInt8 looks and get child 8-bit integer by given keys.
+func (n *Node) GetInt8(keys ...string) (int8, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Int8()
+}
+
+// GetInt16 looks and get child 16-bit integer by given keys.
+func (n *Node) GetInt16(keys ...string) (int16, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Int16()
+}
+
+// GetInt32 looks and get child 32-bit integer by given keys.
+func (n *Node) GetInt32(keys ...string) (int32, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Int32()
+}
+
+// GetUint8 looks and get child 8-bit unsigned integer by given keys.
+func (n *Node) GetUint8(keys ...string) (uint8, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Uint8()
+}
+
+// GetUint16 looks and get child 16-bit unsigned integer by given keys.
+func (n *Node) GetUint16(keys ...string) (uint16, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Uint16()
+}
+
+// GetUint32 looks and get child 32-bit unsigned integer by given keys.
+func (n *Node) GetUint32(keys ...string) (uint32, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Uint32()
+}
+
+// GetBigInt looks and get child arbitrary-precision integer by given keys.
+func (n *Node) GetBigInt(keys ...string) (*big.Int, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return nil, ErrNotFound
+	}
+	return node.BigInt()
+}
+
+// GetBigFloat looks and get child arbitrary-precision float by given keys.
+func (n *Node) GetBigFloat(prec uint, keys ...string) (*big.Float, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return nil, ErrNotFound
+	}
+	return node.BigFloat(prec)
+}
+
+// GetDecimal looks and get child lossless decimal by given keys.
+func (n *Node) GetDecimal(keys ...string) (Decimal, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return Decimal{}, ErrNotFound
+	}
+	return node.Decimal()
+}
+
+// GetTime looks and get child time by given keys using default layouts (see Vector.SetTimeLayouts).
+func (n *Node) GetTime(keys ...string) (time.Time, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Time()
+}
+
+// GetUnix looks and get child time from numeric epoch by given keys.
+func (n *Node) GetUnix(unit time.Duration, keys ...string) (time.Time, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Unix(unit)
+}
+
+// GetDuration looks and get child duration by given keys.
+func (n *Node) GetDuration(keys ...string) (time.Duration, error) {
+	node := n.Get(keys...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Duration()
+}
+
 // GetPS returns child node by path and separator.
 func (n *Node) GetPS(path, separator string) *Node {
 	vec := n.indirectVector()
@@ -264,11 +372,7 @@ func (n *Node) GetBoolPS(path, separator string) bool {
 		return false
 	}
 	vec.splitPath(path, separator)
-	node := n.getKE(path, vec.bufKE...)
-	if node.Type() != TypeBool {
-		return false
-	}
-	return node.Bool()
+	return n.getKE(path, vec.bufKE...).Bool()
 }
 
 // GetFloatPS looks and get child float by given path and separator.
@@ -321,3 +425,171 @@ func (n *Node) GetUintPS(path, separator string) (uint64, error) {
 	}
 	return node.Uint()
 }
+
+// GetInt8PS looks and get child 8-bit integer by given path and separator.
+func (n *Node) GetInt8PS(path, separator string) (int8, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Int8()
+}
+
+// GetInt16PS looks and get child 16-bit integer by given path and separator.
+func (n *Node) GetInt16PS(path, separator string) (int16, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Int16()
+}
+
+// GetInt32PS looks and get child 32-bit integer by given path and separator.
+func (n *Node) GetInt32PS(path, separator string) (int32, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Int32()
+}
+
+// GetUint8PS looks and get child 8-bit unsigned integer by given path and separator.
+func (n *Node) GetUint8PS(path, separator string) (uint8, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Uint8()
+}
+
+// GetUint16PS looks and get child 16-bit unsigned integer by given path and separator.
+func (n *Node) GetUint16PS(path, separator string) (uint16, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Uint16()
+}
+
+// GetUint32PS looks and get child 32-bit unsigned integer by given path and separator.
+func (n *Node) GetUint32PS(path, separator string) (uint32, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Uint32()
+}
+
+// GetBigIntPS looks and get child arbitrary-precision integer by given path and separator.
+func (n *Node) GetBigIntPS(path, separator string) (*big.Int, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return nil, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return nil, ErrNotFound
+	}
+	return node.BigInt()
+}
+
+// GetBigFloatPS looks and get child arbitrary-precision float by given path and separator.
+func (n *Node) GetBigFloatPS(prec uint, path, separator string) (*big.Float, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return nil, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return nil, ErrNotFound
+	}
+	return node.BigFloat(prec)
+}
+
+// GetDecimalPS looks and get child lossless decimal by given path and separator.
+func (n *Node) GetDecimalPS(path, separator string) (Decimal, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return Decimal{}, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return Decimal{}, ErrNotFound
+	}
+	return node.Decimal()
+}
+
+// GetTimePS looks and get child time by given path and separator using default layouts (see Vector.SetTimeLayouts).
+func (n *Node) GetTimePS(path, separator string) (time.Time, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return time.Time{}, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Time()
+}
+
+// GetUnixPS looks and get child time from numeric epoch by given path and separator.
+func (n *Node) GetUnixPS(path, separator string, unit time.Duration) (time.Time, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return time.Time{}, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Unix(unit)
+}
+
+// GetDurationPS looks and get child duration by given path and separator.
+func (n *Node) GetDurationPS(path, separator string) (time.Duration, error) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return 0, ErrInternal
+	}
+	vec.splitPath(path, separator)
+	node := n.getKE(path, vec.bufKE...)
+	if node.typ == TypeNull {
+		return 0, ErrNotFound
+	}
+	return node.Duration()
+}
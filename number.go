@@ -0,0 +1,363 @@
+package vector
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/koykov/byteconv"
+)
+
+// Number parsing options (see FlagNumUnderscore, FlagNumPrefix and FlagNumInfNaN).
+type numOpts uint8
+
+const (
+	numUnderscore numOpts = 1 << iota
+	numPrefix
+	numInfNaN
+)
+
+// Internal error: value isn't an integer, but may be a float.
+var errNotInt = errors.New("not an integer")
+
+// Powers of 10 exactly representable as float64.
+var pow10tab = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11,
+	1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// Get number parsing options of the node's vector.
+func (n *Node) numOpts() (o numOpts) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return
+	}
+	if vec.CheckBit(FlagNumUnderscore) {
+		o |= numUnderscore
+	}
+	if vec.CheckBit(FlagNumPrefix) {
+		o |= numPrefix
+	}
+	if vec.CheckBit(FlagNumInfNaN) {
+		o |= numInfNaN
+	}
+	return
+}
+
+// Parse b as signed integer of given bit size.
+//
+// Exactly integral floats (eg 3.0 or 1e3) are also allowed. Returns ErrOverflow if value doesn't fit bitSize.
+func parseInt(b []byte, bitSize int, o numOpts) (int64, error) {
+	u, neg, err := scanUint(b, o)
+	if err == errNotInt {
+		f, err := parseIntegral(b, o)
+		if err != nil {
+			return 0, err
+		}
+		lim := math.Ldexp(1, bitSize-1)
+		if f < -lim || f >= lim {
+			return 0, ErrOverflow
+		}
+		return int64(f), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	lim := uint64(1) << (bitSize - 1)
+	if neg {
+		if u > lim {
+			return 0, ErrOverflow
+		}
+		return int64(-u), nil
+	}
+	if u >= lim {
+		return 0, ErrOverflow
+	}
+	return int64(u), nil
+}
+
+// Parse b as unsigned integer of given bit size.
+//
+// Exactly integral floats (eg 3.0 or 1e3) are also allowed. Returns ErrOverflow if value doesn't fit bitSize.
+func parseUint(b []byte, bitSize int, o numOpts) (uint64, error) {
+	u, neg, err := scanUint(b, o)
+	if err == errNotInt {
+		f, err := parseIntegral(b, o)
+		if err != nil {
+			return 0, err
+		}
+		if f < 0 || f >= math.Ldexp(1, bitSize) {
+			return 0, ErrOverflow
+		}
+		return uint64(f), nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if (neg && u != 0) || (bitSize < 64 && u >= uint64(1)<<bitSize) {
+		return 0, ErrOverflow
+	}
+	return u, nil
+}
+
+// Parse b as float and check it has no fractional part.
+func parseIntegral(b []byte, o numOpts) (float64, error) {
+	f, err := parseFloat(b, 64, o)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || math.IsInf(f, 0) {
+		return 0, ErrIncompatType
+	}
+	return f, nil
+}
+
+// Scan magnitude and sign of integer.
+//
+// Returns errNotInt if b isn't a valid integer and ErrOverflow if magnitude doesn't fit uint64.
+func scanUint(b []byte, o numOpts) (u uint64, neg bool, err error) {
+	b = trimSpaceASCII(b)
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		neg = b[0] == '-'
+		b = b[1:]
+	}
+	base := uint64(10)
+	if o&numPrefix != 0 && len(b) > 2 && b[0] == '0' {
+		switch b[1] | 0x20 {
+		case 'x':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+		if base != 10 {
+			b = b[2:]
+		}
+	}
+	if len(b) == 0 {
+		return 0, neg, errNotInt
+	}
+	if base == 10 && len(b) < 20 && o&numUnderscore == 0 {
+		// Fast path: up to 19 decimal digits can't overflow.
+		for i := 0; i < len(b); i++ {
+			d := b[i] - '0'
+			if d > 9 {
+				return 0, neg, errNotInt
+			}
+			u = u*10 + uint64(d)
+		}
+		return
+	}
+	var ovf bool
+	cutoff := math.MaxUint64/base + 1
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c == '_' && o&numUnderscore != 0 && i > 0 && i < len(b)-1 && b[i-1] != '_' {
+			continue
+		}
+		d := digitVal(c)
+		if d >= base {
+			return 0, neg, errNotInt
+		}
+		if u >= cutoff {
+			// Keep scanning, value still may be a float.
+			ovf = true
+			continue
+		}
+		u1 := u*base + d
+		ovf = ovf || u1 < u
+		u = u1
+	}
+	if ovf {
+		return 0, neg, ErrOverflow
+	}
+	return
+}
+
+// Parse b as float number of given bit size.
+//
+// Exact cases parse using fast path, other ones use strconv after syntax check.
+func parseFloat(b []byte, bitSize int, o numOpts) (float64, error) {
+	b = trimSpaceASCII(b)
+	s := b
+	var neg bool
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if len(s) == 0 {
+		return 0, ErrBadNumber
+	}
+	if d := s[0] | 0x20; d == 'i' || d == 'n' {
+		return parseInfNaN(s, neg, o)
+	}
+	if o&numPrefix != 0 && len(s) > 2 && s[0] == '0' && (s[1]|0x20 == 'x' || s[1]|0x20 == 'o' || s[1]|0x20 == 'b') {
+		u, neg, err := scanUint(b, o)
+		if err != nil {
+			return 0, ErrBadNumber
+		}
+		f := float64(u)
+		if neg {
+			f = -f
+		}
+		return f, nil
+	}
+
+	var (
+		mant            uint64
+		nd, dp, exp     int
+		point, trunc    bool
+		digits, unders  bool
+		i               int
+		maxMant, maxExp = uint64(1) << 53, 22
+	)
+	if bitSize == 32 {
+		maxMant, maxExp = uint64(1)<<24, 10
+	}
+loop:
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = true
+			if c == '0' && nd == 0 {
+				// Ignore leading zeros.
+				if point {
+					dp--
+				}
+				continue
+			}
+			if nd < 19 {
+				mant = mant*10 + uint64(c-'0')
+				nd++
+				if point {
+					dp--
+				}
+			} else {
+				if !point {
+					dp++
+				}
+				trunc = trunc || c != '0'
+			}
+			continue
+		case c == '.' && !point:
+			point = true
+			continue
+		case c == '_' && o&numUnderscore != 0 && i > 0 && i < len(s)-1 && isDigit(s[i-1]) && isDigit(s[i+1]):
+			unders = true
+			continue
+		}
+		break loop
+	}
+	if !digits {
+		return 0, ErrBadNumber
+	}
+	if i < len(s) {
+		if s[i]|0x20 != 'e' || i+1 == len(s) {
+			return 0, ErrBadNumber
+		}
+		i++
+		esign := 1
+		if s[i] == '+' || s[i] == '-' {
+			if s[i] == '-' {
+				esign = -1
+			}
+			i++
+		}
+		if i == len(s) {
+			return 0, ErrBadNumber
+		}
+		for ; i < len(s); i++ {
+			if !isDigit(s[i]) {
+				return 0, ErrBadNumber
+			}
+			if exp < 10000 {
+				exp = exp*10 + int(s[i]-'0')
+			}
+		}
+		exp *= esign
+	}
+	exp += dp
+
+	// Fast path: both mantissa and power of 10 are exact.
+	if !trunc && mant <= maxMant && exp >= -maxExp && exp <= maxExp {
+		var f float64
+		switch {
+		case bitSize == 32 && exp < 0:
+			f = float64(float32(mant) / float32(pow10tab[-exp]))
+		case bitSize == 32:
+			f = float64(float32(mant) * float32(pow10tab[exp]))
+		case exp < 0:
+			f = float64(mant) / pow10tab[-exp]
+		default:
+			f = float64(mant) * pow10tab[exp]
+		}
+		if neg {
+			f = -f
+		}
+		return f, nil
+	}
+
+	if unders {
+		b = stripUnderscores(b)
+	}
+	f, err := strconv.ParseFloat(byteconv.B2S(b), bitSize)
+	if err != nil {
+		if isRangeErr(err) {
+			return 0, ErrOverflow
+		}
+		return 0, ErrBadNumber
+	}
+	return f, nil
+}
+
+// Parse infinity or NaN if allowed.
+func parseInfNaN(s []byte, neg bool, o numOpts) (float64, error) {
+	if o&numInfNaN == 0 {
+		return 0, ErrBadNumber
+	}
+	switch {
+	case equalFoldASCII(s, "inf") || equalFoldASCII(s, "infinity"):
+		if neg {
+			return math.Inf(-1), nil
+		}
+		return math.Inf(1), nil
+	case equalFoldASCII(s, "nan"):
+		return math.NaN(), nil
+	}
+	return 0, ErrBadNumber
+}
+
+// Remove underscores from b.
+//
+// Short numbers use stack buffer.
+func stripUnderscores(b []byte) []byte {
+	var a [64]byte
+	r := a[:0]
+	for i := 0; i < len(b); i++ {
+		if b[i] != '_' {
+			r = append(r, b[i])
+		}
+	}
+	return r
+}
+
+func digitVal(c byte) uint64 {
+	if d := c - '0'; d < 10 {
+		return uint64(d)
+	}
+	if l := c | 0x20; l >= 'a' && l <= 'f' {
+		return uint64(l-'a') + 10
+	}
+	return math.MaxUint8
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isRangeErr(err error) bool {
+	ne, ok := err.(*strconv.NumError)
+	return ok && ne.Err == strconv.ErrRange
+}
@@ -0,0 +1,162 @@
+package vector
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestNumber(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		stages := []struct {
+			src  string
+			opts numOpts
+			need int64
+			err  error
+		}{
+			{src: "42", need: 42},
+			{src: "+42", need: 42},
+			{src: " -42\n", need: -42},
+			{src: "-9223372036854775808", need: math.MinInt64},
+			{src: "9223372036854775808", err: ErrOverflow},
+			{src: "1e3", need: 1000},
+			{src: "1.5", err: ErrIncompatType},
+			{src: "1_000", err: ErrBadNumber},
+			{src: "1_000", opts: numUnderscore, need: 1000},
+			{src: "1__0", opts: numUnderscore, err: ErrBadNumber},
+			{src: "0xff", err: ErrBadNumber},
+			{src: "0xff", opts: numPrefix, need: 255},
+			{src: "-0o17", opts: numPrefix, need: -15},
+			{src: "0b1010_1010", opts: numPrefix | numUnderscore, need: 170},
+			{src: "", err: ErrBadNumber},
+		}
+		for _, st := range stages {
+			i, err := parseInt([]byte(st.src), 64, st.opts)
+			if err != st.err || i != st.need {
+				t.Errorf("'%s': need %d/%v, got %d/%v", st.src, st.need, st.err, i, err)
+			}
+		}
+	})
+	t.Run("float", func(t *testing.T) {
+		for _, src := range []string{"0", "-0.5", "3.14159", "1e22", "1e-22", "123456789012345678901234", "2.2250738585072014e-308",
+			"0.000001", "1.7976931348623157e308", "9007199254740993", "00012.500"} {
+			f, err := parseFloat([]byte(src), 64, 0)
+			need, _ := strconv.ParseFloat(src, 64)
+			if err != nil || f != need {
+				t.Errorf("'%s': need %v, got %v/%v", src, need, f, err)
+			}
+			f, err = parseFloat([]byte(src), 32, 0)
+			need, nerr := strconv.ParseFloat(src, 32)
+			if nerr != nil {
+				if err != ErrOverflow {
+					t.Errorf("'%s' (32 bit): overflow expected, got %v", src, err)
+				}
+				continue
+			}
+			if err != nil || f != need {
+				t.Errorf("'%s' (32 bit): need %v, got %v/%v", src, need, f, err)
+			}
+		}
+		for _, src := range []string{"1e", "1.2.3", ".", "-", "inf", "nan", "0x10", "1e400"} {
+			if _, err := parseFloat([]byte(src), 64, 0); err == nil {
+				t.Errorf("'%s' must fail", src)
+			}
+		}
+		if f, err := parseFloat([]byte("-Infinity"), 64, numInfNaN); err != nil || !math.IsInf(f, -1) {
+			t.Errorf("inf mismatch: %v/%v", f, err)
+		}
+		if f, err := parseFloat([]byte("NaN"), 64, numInfNaN); err != nil || !math.IsNaN(f) {
+			t.Errorf("nan mismatch: %v/%v", f, err)
+		}
+		if f, err := parseFloat([]byte("1_000.000_1"), 64, numUnderscore); err != nil || f != 1000.0001 {
+			t.Errorf("underscore mismatch: %v/%v", f, err)
+		}
+	})
+	t.Run("flags", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.Reset()
+		vec.Reset()
+		_ = vec.SetSrc([]byte("0x1_F"), false)
+		var p Byteptr
+		_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), 0, 5))
+		if _, err := vec.Root().Int(); err != ErrBadNumber {
+			t.Errorf("prefix must be disabled by default, got %v", err)
+		}
+		vec.SetBit(FlagNumPrefix, true)
+		vec.SetBit(FlagNumUnderscore, true)
+		if i, err := vec.Root().Int(); err != nil || i != 31 {
+			t.Errorf("value mismatch: need 31, got %d/%v", i, err)
+		}
+	})
+}
+
+func TestNumberNode(t *testing.T) {
+	const src = `[1e3, 42,+7,18446744073709551616,1.5]`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitArrayStart()
+	for _, s := range [][2]int{{1, 3}, {5, 3}, {9, 2}, {12, 20}, {33, 3}} {
+		_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), s[0], s[1]))
+	}
+	_ = vec.EmitEnd()
+	root := vec.Root()
+
+	if x, err := root.At(0).Int(); err != nil || x != 1000 {
+		t.Errorf("int mismatch: need 1000, got %d/%v", x, err)
+	}
+	if x, err := root.At(1).Uint(); err != nil || x != 42 {
+		t.Errorf("uint mismatch: need 42, got %d/%v", x, err)
+	}
+	if x, err := root.At(2).Int(); err != nil || x != 7 {
+		t.Errorf("int mismatch: need 7, got %d/%v", x, err)
+	}
+	if _, err := root.At(3).Uint(); err != ErrOverflow {
+		t.Errorf("must fail with overflow, got %v", err)
+	}
+	if _, err := root.At(4).Int(); err != ErrIncompatType {
+		t.Errorf("fractional value must fail, got %v", err)
+	}
+	if x, err := root.At(4).Float(); err != nil || x != 1.5 {
+		t.Errorf("float mismatch: need 1.5, got %f/%v", x, err)
+	}
+	if x, err := root.At(1).Uint8(); err != nil || x != 42 {
+		t.Errorf("uint8 mismatch: need 42, got %d/%v", x, err)
+	}
+}
+
+func BenchmarkNumber(b *testing.B) {
+	const (
+		si = "-1234567890"
+		sf = "12345.6789"
+	)
+	bi, bf := []byte(si), []byte(sf)
+	b.Run("int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = parseInt(bi, 64, 0)
+		}
+	})
+	b.Run("int/strconv", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = strconv.ParseInt(si, 10, 64)
+		}
+	})
+	b.Run("float", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = parseFloat(bf, 64, 0)
+		}
+	})
+	b.Run("float/strconv", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = strconv.ParseFloat(sf, 64)
+		}
+	})
+}
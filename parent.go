@@ -0,0 +1,86 @@
+package vector
+
+import "strconv"
+
+// Parent returns parent node. The NULL node will return for root nodes.
+func (n *Node) Parent() *Node {
+	if p := n.parent(); p != nil {
+		return p
+	}
+	return nullNode
+}
+
+// Root returns root node of the tree that contains the node.
+func (n *Node) Root() *Node {
+	if n.indirectVector() == nil {
+		return nullNode
+	}
+	r := n
+	for p := n.parent(); p != nil; p = p.parent() {
+		r = p
+	}
+	return r
+}
+
+// Ancestors applies closure to each ancestor of the node starting from parent up to the root node.
+//
+// Iteration stops if closure returns false.
+func (n *Node) Ancestors(fn func(node *Node) bool) {
+	for p := n.parent(); p != nil; p = p.parent() {
+		if !fn(p) {
+			return
+		}
+	}
+}
+
+// Path returns path of the node from the root with "." separator.
+//
+// Path consists of keys of object children and indexes of array children, thus it may be used in GetPS/Dot methods.
+func (n *Node) Path() string {
+	return string(n.AppendPath(nil, "."))
+}
+
+// AppendPath appends path of the node from the root with given separator to dst.
+func (n *Node) AppendPath(dst []byte, separator string) []byte {
+	p := n.parent()
+	if p == nil {
+		return dst
+	}
+	off := len(dst)
+	dst = p.AppendPath(dst, separator)
+	if len(dst) > off {
+		dst = append(dst, separator...)
+	}
+	if p.typ == TypeArray {
+		ci := p.childrenIdx()
+		for i := 0; i < len(ci); i++ {
+			if ci[i] == int(n.idx) {
+				return strconv.AppendInt(dst, int64(i), 10)
+			}
+		}
+		return dst
+	}
+	return append(dst, n.key.Bytes()...)
+}
+
+// Get parent node or nil.
+func (n *Node) parent() *Node {
+	vec := n.indirectVector()
+	if vec == nil || n.pidx < 0 || int(n.pidx) >= vec.nodeL {
+		return nil
+	}
+	return &vec.nodes[n.pidx]
+}
+
+// Bind children of the node to it after moving (see SwapWith).
+//
+// Children of aliases are nodes of other parents, thus they stay untouched.
+func (n *Node) adopt(vec *Vector) {
+	if n.typ != TypeObject && n.typ != TypeArray || n.limit <= n.offset {
+		return
+	}
+	ci := vec.Index.get(int(n.depth)+1, int(n.offset), int(n.limit))
+	for i := 0; i < len(ci); i++ {
+		vec.nodes[ci[i]].pidx = n.idx
+	}
+}
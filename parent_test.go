@@ -0,0 +1,45 @@
+package vector
+
+import "testing"
+
+func TestParent(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	testEmit(vec)
+
+	node := vec.Dot("c.1")
+	if p := node.Parent(); p.Type() != TypeArray || p.KeyString() != "c" {
+		t.Error("parent mismatch")
+	}
+	if r := node.Root(); r.Index() != vec.Root().Index() || r.Parent() != nullNode {
+		t.Error("root mismatch")
+	}
+	var depth int
+	node.Ancestors(func(_ *Node) bool {
+		depth++
+		return true
+	})
+	if depth != 2 {
+		t.Errorf("ancestors count mismatch: need 2, got %d", depth)
+	}
+	if p := node.Path(); p != "c.1" {
+		t.Errorf("path mismatch: need 'c.1', got '%s'", p)
+	}
+
+	// Parent links must survive nodes moving.
+	vec.Dot("a").SwapWith(vec.Dot("c"))
+	for _, path := range []string{"a.b", "c.1"} {
+		if p := vec.Dot(path).Path(); p != path {
+			t.Errorf("path mismatch: need '%s', got '%s'", path, p)
+		}
+	}
+	vec.Root().Each(func(_ int, node *Node) {
+		if node.Parent().Index() != vec.Root().Index() {
+			t.Errorf("parent of '%s' mismatch", node.KeyString())
+		}
+	})
+}
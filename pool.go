@@ -0,0 +1,100 @@
+package vector
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Pool is a generic pool of vectors.
+//
+// Vectors resets on release. Vectors that capacities exceed configured thresholds drops from the pool (or shrinks, see
+// Shrink policy) to avoid permanent memory bloat after processing of huge documents.
+type Pool[T Interface] struct {
+	// New makes new vector. Mandatory.
+	New func() T
+	// Capacity thresholds in bytes (see Stats). Zero value disables corresponding check.
+	MaxNodes, MaxIndex, MaxBuf int
+	// Shrink policy of oversize vectors. If set, oversize vectors shrinks and returns to the pool instead of drop.
+	Shrink *ShrinkPolicy
+	// Adaptive estimator shared between all vectors of the pool (see Vector.SetAdaptive).
+	Adaptive *Adaptive
+
+	p sync.Pool
+	// Counters.
+	acq, rel, drop, shrink uint64
+	// High-water marks.
+	hwNodes, hwIndex, hwBuf uint64
+}
+
+// PoolStats represents pool counters.
+type PoolStats struct {
+	// Count of acquired, released, dropped and shrunk vectors.
+	Acquire, Release, Drop, Shrink uint64
+	// High-water marks of nodes, index and buffer usage (in bytes) of released vectors.
+	MaxNodes, MaxIndex, MaxBuf uint64
+}
+
+// Acquire gets vector from the pool or makes new one.
+func (p *Pool[T]) Acquire() T {
+	atomic.AddUint64(&p.acq, 1)
+	if raw := p.p.Get(); raw != nil {
+		return raw.(T)
+	}
+	vec := p.New()
+	if p.Adaptive != nil {
+		vec.SetAdaptive(p.Adaptive)
+	}
+	return vec
+}
+
+// Release resets vector and puts it back to the pool.
+//
+// Vector will be dropped (or shrunk) if any of its capacities exceeds the threshold.
+func (p *Pool[T]) Release(vec T) {
+	atomic.AddUint64(&p.rel, 1)
+	s := vec.Stats()
+	hwm(&p.hwNodes, s.NodesLen)
+	hwm(&p.hwIndex, s.IndexLen)
+	hwm(&p.hwBuf, s.BufLen)
+	oversize := exceeds(s.NodesCap, p.MaxNodes) || exceeds(s.IndexCap, p.MaxIndex) || exceeds(s.BufCap, p.MaxBuf)
+	if !oversize || p.Shrink == nil {
+		vec.Reset()
+	}
+	if oversize {
+		if p.Shrink == nil {
+			atomic.AddUint64(&p.drop, 1)
+			return
+		}
+		// Shrink resets the vector itself, thus no reset above.
+		vec.Shrink(*p.Shrink)
+		atomic.AddUint64(&p.shrink, 1)
+	}
+	p.p.Put(vec)
+}
+
+// Stats returns snapshot of pool counters.
+func (p *Pool[T]) Stats() PoolStats {
+	return PoolStats{
+		Acquire:  atomic.LoadUint64(&p.acq),
+		Release:  atomic.LoadUint64(&p.rel),
+		Drop:     atomic.LoadUint64(&p.drop),
+		Shrink:   atomic.LoadUint64(&p.shrink),
+		MaxNodes: atomic.LoadUint64(&p.hwNodes),
+		MaxIndex: atomic.LoadUint64(&p.hwIndex),
+		MaxBuf:   atomic.LoadUint64(&p.hwBuf),
+	}
+}
+
+// Update high-water mark addr using value v.
+func hwm(addr *uint64, v int) {
+	for {
+		old := atomic.LoadUint64(addr)
+		if uint64(v) <= old || atomic.CompareAndSwapUint64(addr, old, uint64(v)) {
+			return
+		}
+	}
+}
+
+func exceeds(v, limit int) bool {
+	return limit > 0 && v > limit
+}
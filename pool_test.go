@@ -0,0 +1,45 @@
+package vector
+
+import "testing"
+
+func TestPool(t *testing.T) {
+	p := Pool[*Vector]{
+		New:    func() *Vector { return &Vector{} },
+		MaxBuf: 64,
+	}
+	vec := p.Acquire()
+	vec.Bufferize([]byte("foobar"))
+	p.Release(vec)
+
+	vec = p.Acquire()
+	if vec.Stats().BufLen != 0 {
+		t.Error("released vector must be reset")
+	}
+	vec.Bufferize(make([]byte, 128))
+	p.Release(vec)
+
+	s := p.Stats()
+	if s.Acquire != 2 || s.Release != 2 || s.Drop != 1 {
+		t.Errorf("counters mismatch: %+v", s)
+	}
+	if s.MaxBuf != 128 {
+		t.Errorf("high-water mark mismatch: need 128, got %d", s.MaxBuf)
+	}
+
+	t.Run("shrink", func(t *testing.T) {
+		p := Pool[*Vector]{
+			New:    func() *Vector { return &Vector{} },
+			MaxBuf: 64,
+			Shrink: &ShrinkPolicy{Factor: 1},
+		}
+		vec := p.Acquire()
+		vec.Bufferize(make([]byte, 100))
+		p.Release(vec)
+		if s := vec.Stats(); s.BufLen != 0 || s.BufCap != 100 {
+			t.Errorf("released vector must be reset and shrunk to its peak: %+v", s)
+		}
+		if s := p.Stats(); s.Shrink != 1 || s.Drop != 0 {
+			t.Errorf("counters mismatch: %+v", s)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package vector
+
+import (
+	"bytes"
+	"sort"
+)
+
+// SourceSpan returns start and end offsets of the node in the source.
+//
+// Span covers key, value and all children of the node. Delimiters of containers are covered if parser reports their
+// offsets (see Vector.EmitObjectStartAt and EmitEndAt). Returns -1, -1 if node has no data in the source (eg value was
+// set from the buffer). Note, span of the escaped value may be shorter after in-place unescape.
+func (n *Node) SourceSpan() (lo, hi int) {
+	lo, hi = -1, -1
+	if vec := n.indirectVector(); vec != nil {
+		n.sourceSpan(vec, &lo, &hi)
+	}
+	return
+}
+
+// Extend span [lo, hi) using data of the node and its children.
+func (n *Node) sourceSpan(vec *Vector, lo, hi *int) {
+	n.key.sourceSpan(vec, lo, hi)
+	n.val.sourceSpan(vec, lo, hi)
+	if n.typ != TypeObject && n.typ != TypeArray || n.val.CheckBit(bitDeferred) {
+		// Value of deferred node already contains the whole span.
+		return
+	}
+	ci := n.childrenIdx()
+	for i := 0; i < len(ci); i++ {
+		vec.nodes[ci[i]].sourceSpan(vec, lo, hi)
+	}
+}
+
+// Extend span [lo, hi) using p's data if it's located in the source.
+func (p *Byteptr) sourceSpan(vec *Vector, lo, hi *int) {
+	if p.len == 0 {
+		return
+	}
+	start := p.dataAddr()
+	if p.bits.CheckBit(bitBuffered) {
+		// Address of buffered data keeps the original location.
+		start = p.baseAddr()
+	}
+	if start == 0 || start < vec.addr || start >= vec.addr+uintptr(len(vec.src)) {
+		return
+	}
+	s := int(start - vec.addr)
+	e := s + int(p.len)
+	if e > len(vec.src) {
+		e = len(vec.src)
+	}
+	if *lo < 0 || s < *lo {
+		*lo = s
+	}
+	if e > *hi {
+		*hi = e
+	}
+}
+
+// Position returns line and column (both starts from 1) of the given offset in the source.
+//
+// Line table builds lazily on first call. Returns -1, -1 if offset is out of the source.
+func (vec *Vector) Position(offset int) (line, col int) {
+	if offset < 0 || offset > len(vec.src) {
+		return -1, -1
+	}
+	if len(vec.lines) == 0 {
+		vec.lines = append(vec.lines, 0)
+		for i := 0; ; {
+			j := bytes.IndexByte(vec.src[i:], '\n')
+			if j < 0 {
+				break
+			}
+			i += j + 1
+			vec.lines = append(vec.lines, i)
+		}
+	}
+	l := sort.SearchInts(vec.lines, offset+1) - 1
+	return l + 1, offset - vec.lines[l] + 1
+}
@@ -0,0 +1,56 @@
+package vector
+
+import "testing"
+
+func TestPosition(t *testing.T) {
+	const src = "{\"a\":{\"b\":\"foo\"},\n\"c\":[1,2],\"d\":{}}"
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitObjectStartAt(0)
+	_ = vec.EmitKey(p.Init(vec.Src(), 2, 1))
+	_ = vec.EmitObjectStartAt(5)
+	_ = vec.EmitKey(p.Init(vec.Src(), 7, 1))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 11, 3))
+	_ = vec.EmitEndAt(16)
+	_ = vec.EmitKey(p.Init(vec.Src(), 19, 1))
+	_ = vec.EmitArrayStartAt(22)
+	_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), 23, 1))
+	_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), 25, 1))
+	_ = vec.EmitEndAt(27)
+	_ = vec.EmitKey(p.Init(vec.Src(), 29, 1))
+	_ = vec.EmitObjectStartAt(32)
+	_ = vec.EmitEndAt(34)
+	_ = vec.EmitEndAt(35)
+
+	if lo, hi := vec.Dot("a").SourceSpan(); lo != 2 || hi != 16 {
+		t.Errorf("span mismatch: need [2, 16), got [%d, %d)", lo, hi)
+	}
+	if lo, hi := vec.Dot("c").SourceSpan(); lo != 19 || hi != 27 {
+		t.Errorf("span mismatch: need [19, 27), got [%d, %d)", lo, hi)
+	}
+	// Empty container.
+	if lo, hi := vec.Dot("d").SourceSpan(); lo != 29 || hi != 34 {
+		t.Errorf("span mismatch: need [29, 34), got [%d, %d)", lo, hi)
+	}
+	if lo, hi := vec.Root().SourceSpan(); lo != 0 || hi != len(src) {
+		t.Errorf("span mismatch: need [0, %d), got [%d, %d)", len(src), lo, hi)
+	}
+	vec.Dot("a.b").Value().InitString("bar", 0, 3)
+	if lo, hi := vec.Dot("a.b").SourceSpan(); lo != 7 || hi != 8 {
+		t.Errorf("span mismatch: need [7, 8), got [%d, %d)", lo, hi)
+	}
+
+	if line, col := vec.Position(23); line != 2 || col != 6 {
+		t.Errorf("position mismatch: need 2:6, got %d:%d", line, col)
+	}
+	if line, col := vec.Position(1); line != 1 || col != 2 {
+		t.Errorf("position mismatch: need 1:2, got %d:%d", line, col)
+	}
+	if line, _ := vec.Position(len(src) + 1); line != -1 {
+		t.Error("position out of source must fail")
+	}
+}
@@ -0,0 +1,128 @@
+package vector
+
+import "unsafe"
+
+// Savepoint represents saved state of the vector.
+//
+// See Vector.Savepoint and Vector.Rollback.
+type Savepoint struct {
+	// Unique ID of the savepoint, stored in savepoints buffer as well.
+	id int
+	// Length of nodes array, lengths of buffer and read cache, error offset and count of saved nodes.
+	nodeL, bufL, rcL, errOff, snL int
+	// Bounds of index rows lengths and tree builder stack in savepoints buffer.
+	lo, mid, hi int
+}
+
+// Savepoint saves current state of the vector.
+//
+// Use it to try speculative parsing or to stage a batch of edits. All nodes, index rows and buffer data added after the
+// savepoint may be discarded using Rollback. Nodes expanded (see Node.Expand) or unescaped to the read cache
+// after the savepoint restore as well. Savepoints may be nested, but must be rolled back or committed in reverse order.
+func (vec *Vector) Savepoint() Savepoint {
+	vec.spID++
+	sp := Savepoint{
+		id:     vec.spID,
+		nodeL:  vec.nodeL,
+		bufL:   len(vec.buf),
+		rcL:    len(vec.bufRC),
+		errOff: vec.errOff,
+		snL:    len(vec.bufSN),
+		lo:     len(vec.bufSP),
+	}
+	vec.bufSP = append(vec.bufSP, sp.id)
+	vec.bufSP = vec.Index.appendLens(vec.bufSP)
+	sp.mid = len(vec.bufSP)
+	vec.bufSP = append(vec.bufSP, vec.tb.stack...)
+	sp.hi = len(vec.bufSP)
+	return sp
+}
+
+// Rollback restores the state of the vector saved in sp.
+//
+// Restores nodes array length, length of each index row, buffer length and error offset. Children limits of the
+// remaining nodes will be reduced accordingly. Savepoint sp and all savepoints after it will be released. Returns
+// ErrBadSavepoint if sp is already released (including vector reset).
+func (vec *Vector) Rollback(sp Savepoint) error {
+	if !vec.checkSavepoint(sp) {
+		return ErrBadSavepoint
+	}
+	vec.ForgetFrom(sp.nodeL)
+	lens := vec.bufSP[sp.lo+1 : sp.mid]
+	vec.Index.restoreLens(lens)
+	for d := 0; d < len(lens); d++ {
+		var next int
+		if d+1 < len(lens) {
+			next = lens[d+1]
+		}
+		// Any container of the row may own children registered after the savepoint.
+		row := vec.Index.GetRow(d)
+		for i := 0; i < len(row); i++ {
+			node := &vec.nodes[row[i]]
+			if node.typ != TypeObject && node.typ != TypeArray && node.typ != TypeAlias {
+				continue
+			}
+			if int(node.limit) > next {
+				node.limit = nint(next)
+			}
+		}
+	}
+	// Restore changed nodes in reverse order, thus the earliest state wins.
+	for i := len(vec.bufSN) - 1; i >= sp.snL; i-- {
+		if sn := &vec.bufSN[i]; int(sn.idx) < sp.nodeL {
+			vec.nodes[sn.idx] = *sn
+		}
+	}
+	vec.bufSN = vec.bufSN[:sp.snL]
+	if len(vec.buf) > sp.bufL {
+		vec.buf = vec.buf[:sp.bufL]
+	}
+	if len(vec.bufRC) > sp.rcL {
+		vec.bufRC = vec.bufRC[:sp.rcL]
+	}
+	vec.errOff = sp.errOff
+	vec.tb.stack = append(vec.tb.stack[:0], vec.bufSP[sp.mid:sp.hi]...)
+	vec.tb.keyOK = false
+	vec.bufSP = vec.bufSP[:sp.lo]
+	return nil
+}
+
+// Commit releases savepoint sp and all savepoints after it keeping the changes.
+func (vec *Vector) Commit(sp Savepoint) error {
+	if !vec.checkSavepoint(sp) {
+		return ErrBadSavepoint
+	}
+	vec.bufSP = vec.bufSP[:sp.lo]
+	if len(vec.bufSP) == 0 {
+		// Saved nodes are required only by outer savepoints.
+		vec.bufSN = vec.bufSN[:0]
+	}
+	return nil
+}
+
+// Check if sp isn't released yet.
+func (vec *Vector) checkSavepoint(sp Savepoint) bool {
+	return sp.id > 0 && sp.lo < sp.mid && sp.mid <= sp.hi && sp.hi <= len(vec.bufSP) && vec.bufSP[sp.lo] == sp.id &&
+		sp.snL <= len(vec.bufSN)
+}
+
+// Save state of the node before change if any savepoint is active.
+func (vec *Vector) saveNode(node *Node) {
+	if len(vec.bufSP) > 0 {
+		vec.bufSN = append(vec.bufSN, *node)
+	}
+}
+
+// Save state of the node owning p before change if any savepoint is active.
+//
+// Standalone byteptr (not a part of the node) doesn't save.
+func (vec *Vector) savePtr(p *Byteptr) {
+	if len(vec.bufSP) == 0 || vec.nodeL == 0 {
+		return
+	}
+	base, a := uintptr(unsafe.Pointer(&vec.nodes[0])), uintptr(unsafe.Pointer(p))
+	if a < base || a >= base+uintptr(vec.nodeL)*nodeSize {
+		return
+	}
+	vec.bufSN = append(vec.bufSN, vec.nodes[(a-base)/nodeSize])
+}
@@ -0,0 +1,167 @@
+package vector
+
+import "testing"
+
+func TestSavepoint(t *testing.T) {
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+
+	_ = vec.SetSrc([]byte(`{"a":"foo","b":"bar"}`), false)
+	src := vec.Src()
+	var p Byteptr
+	_ = vec.EmitObjectStart()
+	_ = vec.EmitKey(p.Init(src, 2, 1))
+	_ = vec.EmitScalar(TypeString, p.Init(src, 6, 3))
+
+	sp := vec.Savepoint()
+	_ = vec.EmitKey(p.Init(src, 12, 1))
+	_ = vec.EmitObjectStart()
+	_ = vec.EmitKey(p.Init(src, 16, 3))
+	_ = vec.EmitScalar(TypeString, p.Init(src, 16, 3))
+	_ = vec.BufferizeString("garbage")
+	if err := vec.Rollback(sp); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = vec.EmitKey(p.Init(src, 12, 1))
+	_ = vec.EmitScalar(TypeString, p.Init(src, 16, 3))
+	_ = vec.EmitEnd()
+
+	if vec.Len() != 3 || vec.Index.Len(2) != 0 || vec.BufLen() != 0 {
+		t.Errorf("state mismatch: nodes %d, row2 %d, buf %d", vec.Len(), vec.Index.Len(2), vec.BufLen())
+	}
+	if l := vec.Root().Limit(); l != 2 {
+		t.Errorf("limit mismatch: need 2, got %d", l)
+	}
+	if s := vec.DotString("b"); s != "bar" {
+		t.Errorf("value mismatch: need 'bar', got '%s'", s)
+	}
+	if err := vec.Rollback(sp); err != ErrBadSavepoint {
+		t.Errorf("released savepoint must fail")
+	}
+
+	t.Run("stale", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.Reset()
+		vec.Reset()
+		sp := vec.Savepoint()
+		vec.Reset()
+		if err := vec.Rollback(sp); err != ErrBadSavepoint {
+			t.Errorf("savepoint taken before reset must fail, got %v", err)
+		}
+		sp1 := vec.Savepoint()
+		sp2 := vec.Savepoint()
+		if err := vec.Rollback(sp1); err != nil {
+			t.Fatal(err)
+		}
+		// New savepoints occupy the same positions.
+		_, _ = vec.Savepoint(), vec.Savepoint()
+		if err := vec.Rollback(sp2); err != ErrBadSavepoint {
+			t.Errorf("rolled back savepoint must fail, got %v", err)
+		}
+		if err := vec.Commit(sp1); err != ErrBadSavepoint {
+			t.Errorf("rolled back savepoint must fail, got %v", err)
+		}
+	})
+	t.Run("buffered", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+		vec.Reset()
+		vec.SetHelper(testUnescapeHelper{copy: true})
+		_ = vec.SetSrc([]byte(`{"a":"f\oo"}`), false)
+		var p Byteptr
+		_ = vec.EmitObjectStart()
+		_ = vec.EmitKey(p.Init(vec.Src(), 2, 1))
+		_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 6, 4))
+		_ = vec.EmitEnd()
+
+		sp := vec.Savepoint()
+		if s := vec.DotString("a"); s != "foo" {
+			t.Errorf("value mismatch: need 'foo', got '%s'", s)
+		}
+		if err := vec.Rollback(sp); err != nil {
+			t.Fatal(err)
+		}
+		if vec.BufLen() != 0 || vec.Root().At(0).Value().Buffered() {
+			t.Error("buffered value must be restored")
+		}
+		_ = vec.BufferizeString("garbage")
+		if s := vec.DotString("a"); s != "foo" {
+			t.Errorf("value mismatch: need 'foo', got '%s'", s)
+		}
+	})
+	t.Run("lazy", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+		vec.Reset()
+		vec.SetHelper(testLazyHelper{})
+		_ = vec.SetSrc([]byte("[[1,22,333],[4]]"), false)
+		root, ri := vec.AcquireNodeWithType(0, TypeArray)
+		for _, s := range [][2]int{{2, 8}, {13, 1}} {
+			child, i := root.AcquireChildWithType(1, TypeArray)
+			child.Value().Init(vec.Src(), s[0], s[1])
+			child.Defer()
+			root.ReleaseChild(i, child)
+		}
+		vec.ReleaseNode(ri, root)
+
+		sp := vec.Savepoint()
+		if i, _ := vec.DotInt("0.2"); i != 333 {
+			t.Errorf("value mismatch: need 333, got %d", i)
+		}
+		if err := vec.Rollback(sp); err != nil {
+			t.Fatal(err)
+		}
+		if !vec.Dot("0").Deferred() || vec.Len() != 3 {
+			t.Errorf("expanding must be undone, got %d nodes", vec.Len())
+		}
+		if i, err := vec.DotInt("0.2"); err != nil || i != 333 {
+			t.Errorf("value mismatch: need 333, got %d/%v", i, err)
+		}
+		if i, _ := vec.DotInt("1.0"); i != 4 {
+			t.Errorf("value mismatch: need 4, got %d", i)
+		}
+	})
+	t.Run("limit", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.Reset()
+		vec.Reset()
+		_ = vec.SetSrc([]byte(`{"a":{"x":1},"b":2}`), false)
+		// Node pointers must stay valid on acquiring.
+		vec.nodes = make([]Node, 0, 8)
+		src := vec.Src()
+		var p Byteptr
+		_ = vec.EmitObjectStart()
+		_ = vec.EmitKey(p.Init(src, 2, 1))
+		_ = vec.EmitObjectStart()
+		_ = vec.EmitKey(p.Init(src, 7, 1))
+		_ = vec.EmitScalar(TypeNumber, p.Init(src, 10, 1))
+		_ = vec.EmitEnd()
+		_ = vec.EmitKey(p.Init(src, 14, 1))
+		_ = vec.EmitScalar(TypeNumber, p.Init(src, 17, 1))
+		_ = vec.EmitEnd()
+
+		a := vec.Dot("a")
+		sp := vec.Savepoint()
+		child, i := a.AcquireChildWithType(2, TypeNumber)
+		child.Value().Init(src, 17, 1)
+		a.ReleaseChild(i, child)
+		if err := vec.Rollback(sp); err != nil {
+			t.Fatal(err)
+		}
+		a = vec.Dot("a")
+		var c int
+		a.Each(func(_ int, _ *Node) { c++ })
+		if a.Limit() != 1 || c != 1 {
+			t.Errorf("children mismatch: limit %d, count %d", a.Limit(), c)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package vector
+
+// ShrinkPolicy describes target capacities of vector's internal arrays (see Vector.Shrink).
+type ShrinkPolicy struct {
+	// Target capacities in bytes of nodes array, index rows and buffers.
+	Nodes, Index, Buf int
+	// Multiplier of recent peak usage. If greater than zero, targets calculate as peak usage multiplied by factor, but
+	// not less than fixed targets above.
+	Factor float64
+}
+
+// Peak usage of internal arrays in bytes since the last shrink.
+type usage struct {
+	nodes, index, buf int
+}
+
+// Update peak usage using current state of the vector.
+func (u *usage) track(vec *Vector) {
+	if n := vec.nodeL * nodeSize; n > u.nodes {
+		u.nodes = n
+	}
+	var n int
+	for i := 0; i < len(vec.Index.tree); i++ {
+		n += vec.Index.tree[i].len * intSize
+	}
+	if n > u.index {
+		u.index = n
+	}
+	if n = len(vec.buf) + len(vec.bufRC); n > u.buf {
+		u.buf = n
+	}
+}
+
+// Shrink resets the vector and trims capacities of its internal arrays according policy.
+//
+// Use it in long-running workers to bound memory footprint of the vector after processing of huge documents. Peak
+// usage tracks on each reset and starts over after shrink.
+func (vec *Vector) Shrink(policy ShrinkPolicy) {
+	vec.Reset()
+	nodes, index, buf := policy.Nodes, policy.Index, policy.Buf
+	if policy.Factor > 0 {
+		nodes = maxInt(nodes, int(float64(vec.peak.nodes)*policy.Factor))
+		index = maxInt(index, int(float64(vec.peak.index)*policy.Factor))
+		buf = maxInt(buf, int(float64(vec.peak.buf)*policy.Factor))
+	}
+	vec.peak = usage{}
+
+	if n := nodes / nodeSize; cap(vec.nodes) > n {
+		vec.nodes = nil
+		if n > 0 {
+			vec.nodes = make([]Node, 0, n)
+		}
+	}
+
+	// Rows share the budget in order of depth, deeper rows gets the rest.
+	n := index / intSize
+	for i := 0; i < len(vec.Index.tree); i++ {
+		b := &vec.Index.tree[i]
+		if c := cap(b.buf); c > n {
+			b.buf = nil
+			if n > 0 {
+				b.buf = make([]int, 0, n)
+			}
+		}
+		n -= cap(b.buf)
+	}
+
+	if cap(vec.buf) > buf {
+		vec.buf = nil
+		if buf > 0 {
+			vec.buf = make([]byte, 0, buf)
+		}
+	}
+	// Auxiliary buffers are small and may be allocated again on demand.
+	if cap(vec.bufKE)*8 > buf {
+		vec.bufKE = nil
+	}
+	if cap(vec.bufSP)*intSize > buf {
+		vec.bufSP = nil
+	}
+	if cap(vec.bufRC) > buf {
+		vec.bufRC = nil
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
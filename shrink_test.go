@@ -0,0 +1,27 @@
+package vector
+
+import "testing"
+
+func TestShrink(t *testing.T) {
+	// Fresh vector, thus capacities don't depend on previous tests.
+	vec := testPool.New().(*Vector)
+	_ = vec.SetSrc([]byte(`{"a":{"b":"foo"},"c":[1,2]}`), false)
+	testEmit(vec)
+	vec.Bufferize(make([]byte, 4096))
+	vec.Reset()
+	if s := vec.Stats(); s.BufCap < 4096 || s.NodesCap == 0 {
+		t.Fatalf("reset must keep capacity: %+v", s)
+	}
+
+	vec.Shrink(ShrinkPolicy{Buf: 1024})
+	if s := vec.Stats(); s.BufCap != 1024 || s.NodesCap != 0 || s.IndexCap != 0 {
+		t.Errorf("capacity mismatch: %+v", s)
+	}
+
+	vec.Bufferize(make([]byte, 100))
+	vec.Reset()
+	vec.Shrink(ShrinkPolicy{Factor: 2})
+	if s := vec.Stats(); s.BufCap != 200 {
+		t.Errorf("buffer capacity mismatch: need 200, got %d", s.BufCap)
+	}
+}
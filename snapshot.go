@@ -0,0 +1,358 @@
+package vector
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/koykov/bitset"
+	"github.com/koykov/bytealg"
+)
+
+// Snapshot binary format (little endian):
+//
+//	header  [64]byte  magic, version, flags, source bounds, blob length, nodes count, rows count, error offset
+//	blob    []byte    source, buffer, read cache and external data of nodes
+//	nodes   [][72]byte
+//	index   rows: length (uint64) followed by node indices (uint32 each)
+//
+// All pointers are stored as offsets in the blob.
+const (
+	snapshotVersion = 1
+
+	snapshotHeaderSize = 64
+	snapshotNodeSize   = 72
+	snapshotPtrSize    = 24
+
+	// Count of node records to read at once.
+	snapshotChunk = 64
+	// Max size of blob part to read at once.
+	snapshotBlobChunk = 1 << 20
+)
+
+var snapshotMagic = [4]byte{'V', 'E', 'C', 'S'}
+
+// Snapshot header.
+type snapshotHeader struct {
+	version uint32
+	flags   uint64
+	srcOff  uint64
+	srcLen  uint64
+	blobLen uint64
+	nodeL   uint64
+	rows    uint64
+	errOff  uint64
+}
+
+func (h *snapshotHeader) encode(b []byte) {
+	copy(b, snapshotMagic[:])
+	binary.LittleEndian.PutUint32(b[4:], h.version)
+	binary.LittleEndian.PutUint64(b[8:], h.flags)
+	binary.LittleEndian.PutUint64(b[16:], h.srcOff)
+	binary.LittleEndian.PutUint64(b[24:], h.srcLen)
+	binary.LittleEndian.PutUint64(b[32:], h.blobLen)
+	binary.LittleEndian.PutUint64(b[40:], h.nodeL)
+	binary.LittleEndian.PutUint64(b[48:], h.rows)
+	binary.LittleEndian.PutUint64(b[56:], h.errOff)
+}
+
+func (h *snapshotHeader) decode(b []byte) error {
+	if len(b) < snapshotHeaderSize || string(b[:4]) != string(snapshotMagic[:]) {
+		return ErrSnapshotCorrupt
+	}
+	if h.version = binary.LittleEndian.Uint32(b[4:]); h.version != snapshotVersion {
+		return ErrSnapshotVersion
+	}
+	h.flags = binary.LittleEndian.Uint64(b[8:])
+	h.srcOff = binary.LittleEndian.Uint64(b[16:])
+	h.srcLen = binary.LittleEndian.Uint64(b[24:])
+	h.blobLen = binary.LittleEndian.Uint64(b[32:])
+	h.nodeL = binary.LittleEndian.Uint64(b[40:])
+	h.rows = binary.LittleEndian.Uint64(b[48:])
+	h.errOff = binary.LittleEndian.Uint64(b[56:])
+	if h.srcOff > h.blobLen || h.srcLen > h.blobLen-h.srcOff || h.nodeL > 1<<32 {
+		return ErrSnapshotCorrupt
+	}
+	return nil
+}
+
+// WriteSnapshot writes the vector to w in binary format.
+//
+// Snapshot contains nodes, index rows and all referenced bytes (source, buffer and external data), thus it may be
+// loaded later using ReadSnapshot without parsing.
+func (vec *Vector) WriteSnapshot(w io.Writer) error {
+	if err := vec.decodeAll(); err != nil {
+		return err
+	}
+	regs, rc := vec.regions()
+	ext := rc + len(vec.bufRC)
+	h := snapshotHeader{
+		version: snapshotVersion,
+		flags:   uint64(vec.Bitset),
+		srcOff:  uint64(regs[0].off),
+		srcLen:  uint64(regs[0].len),
+		blobLen: uint64(ext + vec.externalLen(&regs)),
+		nodeL:   uint64(vec.nodeL),
+		rows:    uint64(len(vec.Index.tree)),
+		errOff:  uint64(vec.errOff),
+	}
+	var buf [snapshotChunk * snapshotNodeSize]byte
+	h.encode(buf[:])
+	if _, err := w.Write(buf[:snapshotHeaderSize]); err != nil {
+		return err
+	}
+
+	// Write the blob.
+	if regs[1].off == 0 {
+		// Source is a part of the buffer.
+		if _, err := w.Write(vec.buf); err != nil {
+			return err
+		}
+	} else {
+		if _, err := w.Write(vec.src); err != nil {
+			return err
+		}
+		if _, err := w.Write(vec.buf); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(vec.bufRC); err != nil {
+		return err
+	}
+	for i := 0; i < vec.nodeL; i++ {
+		node := &vec.nodes[i]
+		for _, p := range [2]*Byteptr{&node.key, &node.val} {
+			if externalLen(&regs, p) > 0 {
+				if _, err := w.Write(p.RawBytes()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Write nodes.
+	for i := 0; i < vec.nodeL; i++ {
+		node := &vec.nodes[i]
+		b := buf[:snapshotNodeSize]
+		binary.LittleEndian.PutUint32(b[0:], uint32(node.typ))
+		binary.LittleEndian.PutUint32(b[4:], uint32(node.depth))
+		binary.LittleEndian.PutUint32(b[8:], uint32(node.offset))
+		binary.LittleEndian.PutUint32(b[12:], uint32(node.limit))
+		binary.LittleEndian.PutUint32(b[16:], uint32(int32(node.pidx)))
+		binary.LittleEndian.PutUint32(b[20:], 0)
+		var err error
+		if ext, err = encodePtr(b[24:], &regs, &node.key, rc, ext); err != nil {
+			return err
+		}
+		if ext, err = encodePtr(b[24+snapshotPtrSize:], &regs, &node.val, rc, ext); err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	// Write index rows.
+	for i := 0; i < len(vec.Index.tree); i++ {
+		row := vec.Index.GetRow(i)
+		binary.LittleEndian.PutUint64(buf[:], uint64(len(row)))
+		if _, err := w.Write(buf[:8]); err != nil {
+			return err
+		}
+		for len(row) > 0 {
+			n := len(row)
+			if n > len(buf)/4 {
+				n = len(buf) / 4
+			}
+			for j := 0; j < n; j++ {
+				binary.LittleEndian.PutUint32(buf[j*4:], uint32(row[j]))
+			}
+			if _, err := w.Write(buf[:n*4]); err != nil {
+				return err
+			}
+			row = row[n:]
+		}
+	}
+	return nil
+}
+
+// ReadSnapshot loads the vector from snapshot written using WriteSnapshot.
+//
+// Parsing is skipped entirely, all data reads to vector's buffer and pointers rebase to it.
+func (vec *Vector) ReadSnapshot(r io.Reader) (err error) {
+	vec.Reset()
+	defer func() {
+		if err != nil {
+			vec.Reset()
+			vec.buf, vec.src, vec.addr = vec.buf[:0], nil, 0
+		}
+	}()
+
+	var buf [snapshotChunk * snapshotNodeSize]byte
+	if _, err = io.ReadFull(r, buf[:snapshotHeaderSize]); err != nil {
+		return ErrSnapshotCorrupt
+	}
+	var h snapshotHeader
+	if err = h.decode(buf[:snapshotHeaderSize]); err != nil {
+		return
+	}
+
+	// Read the blob by chunks to avoid huge allocations on corrupt header.
+	vec.buf = vec.buf[:0]
+	for off := uint64(0); off < h.blobLen; {
+		n := h.blobLen - off
+		if n > snapshotBlobChunk {
+			n = snapshotBlobChunk
+		}
+		vec.buf = bytealg.GrowDelta(vec.buf, int(n))
+		if _, err = io.ReadFull(r, vec.buf[off:off+n]); err != nil {
+			return ErrSnapshotCorrupt
+		}
+		off += n
+	}
+	vec.src = vec.buf[h.srcOff : h.srcOff+h.srcLen]
+	vec.addr = addrOf(vec.src)
+	vec.selfPtr = vec.ptr()
+	base := addrOf(vec.buf)
+
+	for i := uint64(0); i < h.nodeL; i += snapshotChunk {
+		n := h.nodeL - i
+		if n > snapshotChunk {
+			n = snapshotChunk
+		}
+		chunk := buf[:n*snapshotNodeSize]
+		if _, err = io.ReadFull(r, chunk); err != nil {
+			return ErrSnapshotCorrupt
+		}
+		for j := uint64(0); j < n; j++ {
+			node, _ := vec.ackNode(0)
+			if err = decodeNode(node, chunk[j*snapshotNodeSize:], base, h.blobLen); err != nil {
+				return
+			}
+		}
+	}
+
+	for i := uint64(0); i < h.rows; i++ {
+		if _, err = io.ReadFull(r, buf[:8]); err != nil {
+			return ErrSnapshotCorrupt
+		}
+		l := binary.LittleEndian.Uint64(buf[:8])
+		if l > h.nodeL {
+			return ErrSnapshotCorrupt
+		}
+		for l > 0 {
+			n := l
+			if n > uint64(len(buf)/4) {
+				n = uint64(len(buf) / 4)
+			}
+			if _, err = io.ReadFull(r, buf[:n*4]); err != nil {
+				return ErrSnapshotCorrupt
+			}
+			for j := uint64(0); j < n; j++ {
+				k := binary.LittleEndian.Uint32(buf[j*4:])
+				if uint64(k) >= h.nodeL {
+					return ErrSnapshotCorrupt
+				}
+				vec.Index.Register(int(i), int(k))
+			}
+			l -= n
+		}
+	}
+	if err = vec.checkSnapshot(&h); err != nil {
+		return
+	}
+	vec.errOff = int(h.errOff)
+	vec.Bitset = bitset.Bitset(h.flags)
+	vec.setHelperFlags()
+	return nil
+}
+
+// Check consistency of loaded nodes and index rows.
+func (vec *Vector) checkSnapshot(h *snapshotHeader) error {
+	for i := 0; i < vec.nodeL; i++ {
+		node := &vec.nodes[i]
+		if node.limit > 0 && (node.limit < node.offset || int(node.limit) > vec.Index.Len(int(node.depth)+1)) {
+			return ErrSnapshotCorrupt
+		}
+		if node.pidx >= 0 && uint64(node.pidx) >= h.nodeL {
+			return ErrSnapshotCorrupt
+		}
+	}
+	return nil
+}
+
+// Encode p to b using blob layout described by regs. Read cache is located in the blob at position rc.
+//
+// Snapshot stores 32-bit lengths, thus bigger byteptr (see vector_large build tag) can't be encoded.
+func encodePtr(b []byte, regs *[2]region, p *Byteptr, rc, ext int) (int, error) {
+	if uint64(p.len) > math.MaxUint32 {
+		return ext, ErrSpanOverflow
+	}
+	var pos int
+	var offset span
+	bits := p.bits &^ bitsLayout
+	if p.len > 0 && p.bits.CheckBit(bitBuffered) {
+		// Buffered data stores as regular data in read cache region.
+		pos = rc + int(p.offset)
+		bits.SetBit(bitBuffered, false)
+	} else if p.len > 0 && p.dataAddr() != 0 {
+		var external bool
+		if pos, offset, external = locate(regs, p, ext); external {
+			ext += int(p.len)
+		}
+	}
+	if uint64(offset) > math.MaxUint32 {
+		pos, offset = pos+int(offset), 0
+	}
+	c := uint64(p.capacity())
+	if c > math.MaxUint32 {
+		c = math.MaxUint32
+	}
+	binary.LittleEndian.PutUint64(b[0:], uint64(pos))
+	binary.LittleEndian.PutUint32(b[8:], uint32(offset))
+	binary.LittleEndian.PutUint32(b[12:], uint32(p.len))
+	binary.LittleEndian.PutUint32(b[16:], uint32(c))
+	binary.LittleEndian.PutUint32(b[20:], uint32(bits))
+	return ext, nil
+}
+
+// Decode node record b to node using blob located at base.
+func decodeNode(node *Node, b []byte, base uintptr, blobLen uint64) error {
+	if len(b) < snapshotNodeSize {
+		return ErrSnapshotCorrupt
+	}
+	node.typ = Type(binary.LittleEndian.Uint32(b[0:]))
+	node.depth = nint(binary.LittleEndian.Uint32(b[4:]))
+	node.offset = nint(binary.LittleEndian.Uint32(b[8:]))
+	node.limit = nint(binary.LittleEndian.Uint32(b[12:]))
+	node.pidx = nint(int32(binary.LittleEndian.Uint32(b[16:])))
+	if node.typ > TypeAlias || node.depth < 0 || node.limit < 0 || node.offset < 0 || node.pidx < -1 {
+		return ErrSnapshotCorrupt
+	}
+	if err := decodePtr(&node.key, b[24:], base, blobLen); err != nil {
+		return err
+	}
+	return decodePtr(&node.val, b[24+snapshotPtrSize:], base, blobLen)
+}
+
+// Decode p from b using blob located at base.
+func decodePtr(p *Byteptr, b []byte, base uintptr, blobLen uint64) error {
+	pos := binary.LittleEndian.Uint64(b[0:])
+	offset := binary.LittleEndian.Uint32(b[8:])
+	l := binary.LittleEndian.Uint32(b[12:])
+	c := span(binary.LittleEndian.Uint32(b[16:]))
+	// Buffered data stores as regular one, thus the bit may be set only in corrupt file.
+	p.bits = bitset.Bitset32(binary.LittleEndian.Uint32(b[20:]))&^(bitsLayout|1<<bitBuffered) | p.bits&bitsLayout
+	p.setBaseAddr(0)
+	p.setCap(c)
+	p.offset, p.len = 0, 0
+	if l == 0 {
+		return nil
+	}
+	if pos > blobLen || uint64(offset)+uint64(l) > blobLen-pos {
+		return ErrSnapshotCorrupt
+	}
+	p.setBaseAddr(base + uintptr(pos))
+	p.offset, p.len = span(offset), span(l)
+	return nil
+}
@@ -0,0 +1,45 @@
+package vector
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	vec, cpy := testPool.Get().(*Vector), testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer testPool.Put(cpy)
+	defer vec.Reset()
+	defer cpy.Reset()
+	vec.Reset()
+
+	_ = vec.SetSrc([]byte(src), false)
+	testEmit(vec)
+	vec.Dot("c.0").Value().Init(vec.BufferizeString("15"), 0, 2)
+	vec.Dot("a.b").Value().InitString("bar", 0, 3)
+
+	var buf bytes.Buffer
+	if err := vec.WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snap := buf.Bytes()
+	if err := cpy.ReadSnapshot(bytes.NewReader(snap)); err != nil {
+		t.Fatal(err)
+	}
+	if !cpy.EqualWith(vec) || string(cpy.Src()) != src {
+		t.Error("vectors mismatch")
+	}
+	if s := cpy.DotString("a.b"); s != "bar" {
+		t.Errorf("value mismatch: need 'bar', got '%s'", s)
+	}
+	if i, _ := cpy.DotInt("c.0"); i != 15 {
+		t.Errorf("value mismatch: need 15, got %d", i)
+	}
+
+	for _, l := range []int{0, 10, 100, len(snap) - 1} {
+		if err := cpy.ReadSnapshot(bytes.NewReader(snap[:l])); err != ErrSnapshotCorrupt {
+			t.Errorf("truncated snapshot (%d) must fail, got %v", l, err)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package vector
+
+import "unsafe"
+
+const intSize = int(unsafe.Sizeof(int(0)))
+
+// Stats represents lengths and capacities of vector's internal arrays in bytes.
+type Stats struct {
+	// Nodes array.
+	NodesLen, NodesCap int
+	// Index rows.
+	IndexLen, IndexCap int
+	// Buffer and read cache of unescaped values.
+	BufLen, BufCap int
+	// Source data.
+	SrcLen, SrcCap int
+}
+
+// Stats returns current lengths and capacities of the vector's internal arrays.
+func (vec *Vector) Stats() (s Stats) {
+	s.NodesLen, s.NodesCap = vec.nodeL*nodeSize, cap(vec.nodes)*nodeSize
+	for i := 0; i < len(vec.Index.tree); i++ {
+		b := &vec.Index.tree[i]
+		s.IndexLen += b.len * intSize
+		s.IndexCap += cap(b.buf) * intSize
+	}
+	s.BufLen, s.BufCap = len(vec.buf)+len(vec.bufRC), cap(vec.buf)+cap(vec.bufRC)
+	s.SrcLen, s.SrcCap = len(vec.src), cap(vec.src)
+	return
+}
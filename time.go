@@ -0,0 +1,173 @@
+package vector
+
+import (
+	"bytes"
+	"math"
+	"time"
+
+	"github.com/koykov/byteconv"
+)
+
+// SetTimeLayouts sets default layouts list of Time method.
+//
+// Layouts try in order, the first successfully parsed one wins. Layouts keep after Reset.
+func (vec *Vector) SetTimeLayouts(layouts ...string) {
+	vec.tls = append(vec.tls[:0], layouts...)
+}
+
+// TimeLayouts returns default layouts list of Time method.
+func (vec *Vector) TimeLayouts() []string {
+	return vec.tls
+}
+
+// Time returns value as time parsed using given layouts.
+//
+// If no layouts given, vector's default list uses (see SetTimeLayouts). If the list is empty too, RFC3339 uses.
+func (n *Node) Time(layouts ...string) (time.Time, error) {
+	if n.typ != TypeString && n.typ != TypeAttribute {
+		return time.Time{}, ErrIncompatType
+	}
+	if len(layouts) == 0 {
+		if vec := n.indirectVector(); vec != nil && len(vec.tls) > 0 {
+			layouts = vec.tls
+		}
+	}
+	s := byteconv.B2S(trimSpaceASCII(n.val.Bytes()))
+	if len(layouts) == 0 {
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	var err error
+	for i := 0; i < len(layouts); i++ {
+		var t time.Time
+		if t, err = time.Parse(layouts[i], s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// Unix returns value as time from numeric epoch in given units.
+//
+// Unit may be time.Second, time.Millisecond, time.Microsecond, time.Nanosecond or any other unit that divides or
+// multiple of second. Numeric strings and fractional values are also allowed.
+func (n *Node) Unix(unit time.Duration) (time.Time, error) {
+	if (n.typ != TypeNumber && n.typ != TypeString) || unit <= 0 {
+		return time.Time{}, ErrIncompatType
+	}
+	b, o := n.val.Bytes(), n.numOpts()
+	i, err := parseInt(b, 64, o)
+	if err == ErrIncompatType {
+		// Fractional epoch, eg 1700000000.123.
+		var f float64
+		if f, err = parseFloat(b, 64, o); err != nil {
+			return time.Time{}, err
+		}
+		ns := f * float64(unit)
+		if math.Abs(ns) >= math.MaxInt64 {
+			return time.Time{}, ErrOverflow
+		}
+		return time.Unix(0, int64(ns)), nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch {
+	case unit%time.Second == 0:
+		m := int64(unit / time.Second)
+		if i > math.MaxInt64/m || i < math.MinInt64/m {
+			return time.Time{}, ErrOverflow
+		}
+		return time.Unix(i*m, 0), nil
+	case time.Second%unit == 0:
+		per := int64(time.Second / unit)
+		return time.Unix(i/per, i%per*int64(unit)), nil
+	}
+	return time.Time{}, ErrIncompatType
+}
+
+// Duration returns value as duration.
+//
+// Both Go (eg "1h30m") and ISO-8601 (eg "PT1H30M") formats are allowed. ISO years and months aren't supported due to
+// variable length.
+func (n *Node) Duration() (time.Duration, error) {
+	if n.typ != TypeString && n.typ != TypeAttribute {
+		return 0, ErrIncompatType
+	}
+	b := trimSpaceASCII(n.val.Bytes())
+	if s := trimSign(b); len(s) > 0 && s[0]|0x20 == 'p' {
+		return parseISODuration(b)
+	}
+	return time.ParseDuration(byteconv.B2S(b))
+}
+
+// Parse ISO-8601 duration, eg "P1DT2H30M" or "-PT0.5S".
+func parseISODuration(b []byte) (time.Duration, error) {
+	s := trimSign(b)
+	neg := len(s) < len(b) && b[0] == '-'
+	// Skip designator.
+	s = s[1:]
+	if len(s) == 0 {
+		return 0, ErrBadDuration
+	}
+	var (
+		d         float64
+		timePart  bool
+		component bool
+	)
+	for len(s) > 0 {
+		if s[0]|0x20 == 't' {
+			if timePart || len(s) == 1 {
+				return 0, ErrBadDuration
+			}
+			timePart = true
+			s = s[1:]
+			continue
+		}
+		i := 0
+		for i < len(s) && (isDigit(s[i]) || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, ErrBadDuration
+		}
+		num := s[:i]
+		if c := bytes.IndexByte(num, ','); c >= 0 {
+			// Comma is an allowed decimal separator.
+			var a [32]byte
+			num = append(a[:0], num...)
+			num[c] = '.'
+		}
+		v, err := parseFloat(num, 64, 0)
+		if err != nil {
+			return 0, ErrBadDuration
+		}
+		var unit time.Duration
+		switch c := s[i] | 0x20; {
+		case !timePart && c == 'w':
+			unit = 7 * 24 * time.Hour
+		case !timePart && c == 'd':
+			unit = 24 * time.Hour
+		case timePart && c == 'h':
+			unit = time.Hour
+		case timePart && c == 'm':
+			unit = time.Minute
+		case timePart && c == 's':
+			unit = time.Second
+		default:
+			return 0, ErrBadDuration
+		}
+		d += v * float64(unit)
+		component = true
+		s = s[i+1:]
+	}
+	if !component {
+		return 0, ErrBadDuration
+	}
+	if d >= math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	if neg {
+		d = -d
+	}
+	return time.Duration(math.Round(d)), nil
+}
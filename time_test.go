@@ -0,0 +1,56 @@
+package vector
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	const src = `["2024-05-01T10:00:00Z","01.05.2024",1714557600123,"1714557600.5","PT1H30M","-P1DT0.5S","1h30m","P1M"]`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.SetTimeLayouts()
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitArrayStart()
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 2, 20))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 25, 10))
+	_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), 37, 13))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 52, 12))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 67, 7))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 77, 9))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 89, 5))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 97, 3))
+	_ = vec.EmitEnd()
+
+	need := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if tm, err := vec.DotTime("0"); err != nil || !tm.Equal(need) {
+		t.Errorf("time mismatch: %v, %v", tm, err)
+	}
+	if _, err := vec.DotTime("1"); err == nil {
+		t.Error("time with unknown layout must fail")
+	}
+	vec.SetTimeLayouts(time.RFC3339, "02.01.2006")
+	if tm, err := vec.DotTime("1"); err != nil || !tm.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("time mismatch: %v, %v", tm, err)
+	}
+
+	if tm, err := vec.DotUnix("2", time.Millisecond); err != nil || !tm.Equal(need.Add(123*time.Millisecond)) {
+		t.Errorf("unix mismatch: %v, %v", tm, err)
+	}
+	if tm, err := vec.Root().DotUnix("3", time.Second); err != nil || !tm.Equal(need.Add(500*time.Millisecond)) {
+		t.Errorf("unix mismatch: %v, %v", tm, err)
+	}
+
+	durations := []time.Duration{90 * time.Minute, -(24*time.Hour + 500*time.Millisecond), 90 * time.Minute}
+	for i, d := range durations {
+		if r, err := vec.Root().At(i + 4).Duration(); err != nil || r != d {
+			t.Errorf("duration #%d mismatch: need %v, got %v/%v", i, d, r, err)
+		}
+	}
+	if _, err := vec.DotDuration("7"); err != ErrBadDuration {
+		t.Errorf("months must fail, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package vector
+
+import "strconv"
+
+// Scalar is a constraint of types supported by typed getters (see Get, GetOr and Lookup).
+type Scalar interface {
+	string | []byte | bool |
+		int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64
+}
+
+// Get looks child node by given path and "." separator and returns its value as T.
+//
+// Empty path means the node itself. Returns ErrNotFound if node doesn't exist and ErrIncompatType if value can't be
+// represented as T. By default, value must have exactly matching type, FlagLenient enables type coercion, eg "42" may
+// be read as int and 1 as bool.
+func Get[T Scalar](node *Node, path string) (T, error) {
+	if len(path) > 0 {
+		node = node.Dot(path)
+	}
+	var t T
+	if node == nil || node == nullNode || node.typ == TypeUnknown {
+		return t, ErrNotFound
+	}
+	err := node.scan(&t)
+	return t, err
+}
+
+// GetOr is a version of Get that returns def if value is missing or incompatible.
+func GetOr[T Scalar](node *Node, path string, def T) T {
+	t, err := Get[T](node, path)
+	if err != nil {
+		return def
+	}
+	return t
+}
+
+// Lookup is a version of Get that reports if value exists and compatible with T.
+//
+// Allows to distinguish zero value from missing, eg false from absent bool.
+func Lookup[T Scalar](node *Node, path string) (T, bool) {
+	t, err := Get[T](node, path)
+	return t, err == nil
+}
+
+// Scan value of the node to x.
+func (n *Node) scan(x any) (err error) {
+	lenient := n.lenient()
+	switch p := x.(type) {
+	case *string:
+		if n.typ == TypeString || n.typ == TypeAttribute || (lenient && (n.typ == TypeNumber || n.typ == TypeBool)) {
+			*p = n.val.String()
+			return
+		}
+		return ErrIncompatType
+	case *[]byte:
+		if n.typ == TypeString || n.typ == TypeAttribute || (lenient && (n.typ == TypeNumber || n.typ == TypeBool)) {
+			*p = n.val.Bytes()
+			return
+		}
+		return ErrIncompatType
+	case *bool:
+		*p, err = n.scanBool(lenient)
+	case *int:
+		var i int64
+		i, err = n.scanInt(strconv.IntSize, lenient)
+		*p = int(i)
+	case *int8:
+		var i int64
+		i, err = n.scanInt(8, lenient)
+		*p = int8(i)
+	case *int16:
+		var i int64
+		i, err = n.scanInt(16, lenient)
+		*p = int16(i)
+	case *int32:
+		var i int64
+		i, err = n.scanInt(32, lenient)
+		*p = int32(i)
+	case *int64:
+		*p, err = n.scanInt(64, lenient)
+	case *uint:
+		var u uint64
+		u, err = n.scanUint(strconv.IntSize, lenient)
+		*p = uint(u)
+	case *uint8:
+		var u uint64
+		u, err = n.scanUint(8, lenient)
+		*p = uint8(u)
+	case *uint16:
+		var u uint64
+		u, err = n.scanUint(16, lenient)
+		*p = uint16(u)
+	case *uint32:
+		var u uint64
+		u, err = n.scanUint(32, lenient)
+		*p = uint32(u)
+	case *uint64:
+		*p, err = n.scanUint(64, lenient)
+	case *float32:
+		var f float64
+		f, err = n.scanFloat(32, lenient)
+		*p = float32(f)
+	case *float64:
+		*p, err = n.scanFloat(64, lenient)
+	default:
+		err = ErrIncompatType
+	}
+	return
+}
+
+func (n *Node) scanBool(lenient bool) (bool, error) {
+	if b, err := n.BoolStrict(); err == nil {
+		return b, nil
+	}
+	switch {
+	case lenient && n.typ == TypeNumber:
+		f, err := parseFloat(n.val.Bytes(), 64, n.numOpts())
+		if err != nil {
+			return false, ErrIncompatType
+		}
+		return f != 0, nil
+	case lenient && n.typ == TypeString:
+		b, err := strconv.ParseBool(n.val.String())
+		if err != nil {
+			return false, ErrIncompatType
+		}
+		return b, nil
+	}
+	return false, ErrIncompatType
+}
+
+func (n *Node) scanInt(bitSize int, lenient bool) (int64, error) {
+	b, ok := n.numeric(lenient)
+	if !ok {
+		return 0, ErrIncompatType
+	}
+	return parseInt(b, bitSize, n.numOpts())
+}
+
+func (n *Node) scanUint(bitSize int, lenient bool) (uint64, error) {
+	b, ok := n.numeric(lenient)
+	if !ok {
+		return 0, ErrIncompatType
+	}
+	return parseUint(b, bitSize, n.numOpts())
+}
+
+func (n *Node) scanFloat(bitSize int, lenient bool) (float64, error) {
+	b, ok := n.numeric(lenient)
+	if !ok {
+		return 0, ErrIncompatType
+	}
+	return parseFloat(b, bitSize, n.numOpts())
+}
+
+// Get bytes of numeric value.
+//
+// In lenient mode strings and bools (as 0/1) are also allowed.
+func (n *Node) numeric(lenient bool) ([]byte, bool) {
+	switch {
+	case n.typ == TypeNumber, lenient && n.typ == TypeString:
+		return n.val.Bytes(), true
+	case lenient && n.typ == TypeBool:
+		if n.Bool() {
+			return bOne, true
+		}
+		return bZero, true
+	}
+	return nil, false
+}
+
+// Check if vector of the node allows type coercion.
+func (n *Node) lenient() bool {
+	vec := n.indirectVector()
+	return vec != nil && vec.CheckBit(FlagLenient)
+}
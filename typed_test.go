@@ -0,0 +1,105 @@
+package vector
+
+import "testing"
+
+func TestTyped(t *testing.T) {
+	const src = `{"s":"42","n":300,"b":true}`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitObjectStart()
+	_ = vec.EmitKey(p.Init(vec.Src(), 2, 1))
+	_ = vec.EmitScalar(TypeString, p.Init(vec.Src(), 6, 2))
+	_ = vec.EmitKey(p.Init(vec.Src(), 11, 1))
+	_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), 14, 3))
+	_ = vec.EmitKey(p.Init(vec.Src(), 19, 1))
+	_ = vec.EmitScalar(TypeBool, p.Init(vec.Src(), 22, 4))
+	_ = vec.EmitEnd()
+	root := vec.Root()
+
+	t.Run("strict", func(t *testing.T) {
+		if s, err := Get[string](root, "s"); err != nil || s != "42" {
+			t.Errorf("string mismatch: %s, %v", s, err)
+		}
+		if i, err := Get[int](root, "n"); err != nil || i != 300 {
+			t.Errorf("int mismatch: %d, %v", i, err)
+		}
+		if _, err := Get[int](root, "s"); err != ErrIncompatType {
+			t.Errorf("string as int must fail, got %v", err)
+		}
+		if _, err := Get[uint8](root, "n"); err == nil {
+			t.Error("uint8 overflow must fail")
+		}
+		if _, err := Get[bool](root, "x"); err != ErrNotFound {
+			t.Errorf("missing must fail with ErrNotFound, got %v", err)
+		}
+		if b, ok := Lookup[bool](root, "b"); !ok || !b {
+			t.Error("bool lookup failed")
+		}
+		if _, ok := Lookup[bool](root, "x"); ok {
+			t.Error("missing lookup must fail")
+		}
+		if f := GetOr[float64](root, "x", 1.5); f != 1.5 {
+			t.Errorf("default mismatch: %f", f)
+		}
+	})
+	t.Run("lenient", func(t *testing.T) {
+		vec.SetBit(FlagLenient, true)
+		defer vec.SetBit(FlagLenient, false)
+		if i, err := Get[int32](root, "s"); err != nil || i != 42 {
+			t.Errorf("int mismatch: %d, %v", i, err)
+		}
+		if b, err := Get[bool](root, "n"); err != nil || !b {
+			t.Errorf("bool mismatch: %v, %v", b, err)
+		}
+		if u, err := Get[uint](root, "b"); err != nil || u != 1 {
+			t.Errorf("uint mismatch: %d, %v", u, err)
+		}
+		if s := GetOr[string](root, "n", ""); s != "300" {
+			t.Errorf("string mismatch: %s", s)
+		}
+	})
+}
+
+func TestIntWidth(t *testing.T) {
+	const src = `[300,-129,3.0,3.5,-1]`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	var p Byteptr
+	_ = vec.EmitArrayStart()
+	for _, s := range [][2]int{{1, 3}, {5, 4}, {10, 3}, {14, 3}, {18, 2}} {
+		_ = vec.EmitScalar(TypeNumber, p.Init(vec.Src(), s[0], s[1]))
+	}
+	_ = vec.EmitEnd()
+
+	if i, err := vec.DotInt16("0"); err != nil || i != 300 {
+		t.Errorf("int16 mismatch: %d, %v", i, err)
+	}
+	if _, err := vec.DotUint8("0"); err != ErrOverflow {
+		t.Errorf("uint8 overflow expected, got %v", err)
+	}
+	if _, err := vec.DotInt8("1"); err != ErrOverflow {
+		t.Errorf("int8 overflow expected, got %v", err)
+	}
+	if i, err := vec.DotInt32("2"); err != nil || i != 3 {
+		t.Errorf("integral float mismatch: %d, %v", i, err)
+	}
+	if _, err := vec.DotInt32("3"); err != ErrIncompatType {
+		t.Errorf("fractional float must fail, got %v", err)
+	}
+	if _, err := vec.Root().DotUint32("4"); err != ErrOverflow {
+		t.Errorf("negative uint32 must overflow, got %v", err)
+	}
+	if _, err := vec.Root().DotUint16("9"); err != ErrNotFound {
+		t.Errorf("missing must fail with ErrNotFound, got %v", err)
+	}
+	if _, err := Get[int8](vec.Root(), "0"); err != ErrOverflow {
+		t.Errorf("generic int8 overflow expected, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package vector
+
+import (
+	"io"
+	"runtime"
+	"testing"
+)
+
+// Helper that removes backslashes in place.
+type testUnescapeHelper struct {
+	copy  bool
+	calls *int
+}
+
+func (h testUnescapeHelper) Indirect(p *Byteptr) []byte {
+	if h.calls != nil {
+		*h.calls++
+	}
+	b := p.RawBytes()
+	n := 0
+	for i := 0; i < len(b); i++ {
+		if b[i] != '\\' {
+			b[n] = b[i]
+			n++
+		}
+	}
+	p.SetLen(n)
+	return b[:n]
+}
+func (testUnescapeHelper) Beautify(_ io.Writer, _ *Node) error { return ErrNotImplement }
+func (testUnescapeHelper) Marshal(_ io.Writer, _ *Node) error  { return ErrNotImplement }
+func (h testUnescapeHelper) CopyUnescape() bool                { return h.copy }
+
+func TestCopyUnescape(t *testing.T) {
+	const src = `{"a":{"b":"f\o\o"},"c":[1,2]}`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.SetHelper(nil)
+	defer vec.Reset()
+	vec.Reset()
+	vec.SetHelper(testUnescapeHelper{copy: true})
+	_ = vec.SetSrc([]byte(src), false)
+	testEmit(vec)
+	node := vec.Dot("a.b")
+	node.Value().InitRaw(vec.SrcAddr(), 11, 5)
+
+	if s := node.String(); s != "foo" {
+		t.Errorf("value mismatch: need 'foo', got '%s'", s)
+	}
+	if !node.Value().Buffered() || string(vec.Src()) != src {
+		t.Error("source must stay untouched")
+	}
+	// Buffer growth mustn't break buffered data.
+	vec.Bufferize(make([]byte, 1024))
+	if s := node.RawBytes(); string(s) != "foo" {
+		t.Errorf("value mismatch after buffer growth: need 'foo', got '%s'", s)
+	}
+
+	var cpy Vector
+	if err := vec.CloneTo(&cpy); err != nil {
+		t.Fatal(err)
+	}
+	if s := cpy.Dot("a.b").RawBytes(); string(s) != "foo" {
+		t.Errorf("clone value mismatch: need 'foo', got '%s'", s)
+	}
+}
+
+// Helper that returns value from static storage.
+type testStaticHelper struct{}
+
+func (testStaticHelper) Indirect(_ *Byteptr) []byte          { return []byte("static") }
+func (testStaticHelper) Beautify(_ io.Writer, _ *Node) error { return ErrNotImplement }
+func (testStaticHelper) Marshal(_ io.Writer, _ *Node) error  { return ErrNotImplement }
+
+func TestIndirected(t *testing.T) {
+	t.Run("in-place", func(t *testing.T) {
+		var calls int
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+		vec.Reset()
+		vec.SetHelper(testUnescapeHelper{calls: &calls})
+		_ = vec.SetSrc([]byte(`f\o\o`), false)
+		node, _ := vec.AcquireNodeWithType(0, TypeString)
+		node.Value().Init(vec.Src(), 0, 5)
+		for i := 0; i < 3; i++ {
+			if s := node.String(); s != "foo" {
+				t.Errorf("value mismatch: need 'foo', got '%s'", s)
+			}
+		}
+		if calls != 1 || !node.Value().Indirected() {
+			t.Errorf("helper must be called once, got %d", calls)
+		}
+	})
+	t.Run("static", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+		vec.Reset()
+		vec.SetHelper(testStaticHelper{})
+		_ = vec.SetSrc([]byte("foo"), false)
+		node, _ := vec.AcquireNodeWithType(0, TypeString)
+		node.Value().Init(vec.Src(), 0, 3)
+		_ = node.String()
+		vec.SetHelper(nil)
+		if s := node.String(); s != "static" {
+			t.Errorf("value mismatch: need 'static', got '%s'", s)
+		}
+	})
+	t.Run("buffer", func(t *testing.T) {
+		vec := testPool.Get().(*Vector)
+		defer testPool.Put(vec)
+		defer vec.SetHelper(nil)
+		defer vec.Reset()
+
+		vec.SetHelper(testUnescapeHelper{copy: true})
+		_ = vec.SetSrc([]byte(`f\oo`), false)
+		num, _ := vec.AcquireNodeWithType(0, TypeNumber)
+		num.Value().Init(vec.Bufferize([]byte("15")), 0, 2)
+		bufL := vec.BufLen()
+		for i := 0; i < 64; i++ {
+			node, _ := vec.AcquireNodeWithType(0, TypeString)
+			node.Value().Init(vec.Src(), 0, 4)
+			if s := node.String(); s != "foo" {
+				t.Errorf("value mismatch: need 'foo', got '%s'", s)
+			}
+		}
+		runtime.GC()
+		if vec.BufLen() != bufL {
+			t.Errorf("reading must not grow the buffer: need %d, got %d", bufL, vec.BufLen())
+		}
+		if s := vec.NodeAt(0).String(); s != "15" {
+			t.Errorf("value mismatch: need '15', got '%s'", s)
+		}
+		if vec.NodeAt(1).Value().SetLen(4).Indirected() {
+			t.Error("length change must drop cached result")
+		}
+
+		// Reused nodes must not keep state of the previous data.
+		vec.SetBit(FlagNoClear, true)
+		vec.Reset()
+		_ = vec.SetSrc([]byte(`f\oo`), false)
+		node, _ := vec.AcquireNodeWithType(0, TypeString)
+		if node.Value().Indirected() || node.Value().Buffered() {
+			t.Error("reused node must be clean")
+		}
+	})
+}
@@ -24,6 +24,17 @@ type Vector struct {
 	// Buffers.
 	buf   []byte
 	bufKE []entry.Entry64
+	bufSP []int
+	// Read cache: values unescaped by read methods (see FlagCopyUnescape). It's separated from buf, thus reading never
+	// moves data bound to the buffer.
+	bufRC []byte
+	// Nodes saved before changes made after savepoint (see Rollback) and the last savepoint ID.
+	bufSN []Node
+	spID  int
+	// Walk frames buffer.
+	bufWK []walkFrame
+	// Line table of the source (offsets of lines beginnings).
+	lines []int
 	// Self pointer.
 	selfPtr uintptr
 	// List of nodes and length of it.
@@ -35,6 +46,23 @@ type Vector struct {
 	Index Index
 	// External helper object.
 	Helper Helper
+	// Events handler and default tree builder.
+	evh EventHandler
+	tb  TreeBuilder
+	// Interest set of paths.
+	ints interest
+	// Memory mapped snapshot.
+	mm *mapping
+	// Peak usage of internal arrays.
+	peak usage
+	// Adaptive pre-size estimator.
+	adp *Adaptive
+	// Default time layouts.
+	tls []string
+	// Encoding of binary values.
+	benc BinaryEncoding
+	// Extra truthy and falsy words.
+	btrue, bfalse []string
 }
 
 // Parse parses source bytes.
@@ -121,6 +149,7 @@ func (vec *Vector) SetSrc(s []byte, copy bool) error {
 	} else {
 		vec.src = s
 	}
+	vec.lines = vec.lines[:0]
 	// Get source data address.
 	h := (*byteconv.SliceHeader)(unsafe.Pointer(&vec.src))
 	vec.addr = h.Data
@@ -274,6 +303,7 @@ func (vec *Vector) AcquireChild(root *Node, depth int) (*Node, int) {
 func (vec *Vector) AcquireChildWithType(root *Node, depth int, typ Type) (*Node, int) {
 	node, idx := vec.ackNode(depth)
 	node.typ = typ
+	node.pidx = root.idx
 	root.SetLimit(vec.Index.Register(depth, idx))
 	return node, idx
 }
@@ -289,17 +319,19 @@ func (vec *Vector) ackNode(depth int) (*Node, int) {
 	var node *Node
 	if vec.nodeL < n {
 		node = &vec.nodes[vec.nodeL]
+		// Reused node may keep data of previous parsing (see FlagNoClear).
+		node.key.bits &^= bitsReserved
+		node.val.bits &^= bitsReserved
 	} else {
 		vec.nodes = append(vec.nodes, Node{typ: TypeUnknown})
 		node = &vec.nodes[n]
 	}
-	node.depth = depth
-	node.vptr = vec.selfPtr
-	node.key.vptr = node.vptr
-	node.val.vptr = node.vptr
-	node.idx = vec.nodeL
+	node.depth = nint(depth)
+	node.pidx = -1
+	node.bind(vec.selfPtr)
+	node.idx = nint(vec.nodeL)
 	vec.nodeL++
-	return node, node.idx
+	return node, vec.nodeL - 1
 }
 
 // ReleaseNode returns node back to the vector.
@@ -330,20 +362,31 @@ func (vec *Vector) Prealloc(size uint) {
 
 // Reset vector data.
 func (vec *Vector) Reset() {
-	if vec.nodeL == 0 {
-		return
+	vec.closeSnapshot()
+	vec.peak.track(vec)
+	if vec.adp != nil {
+		vec.adp.observe(vec)
 	}
-	if !vec.CheckBit(FlagNoClear) {
+	if vec.nodeL > 0 && !vec.CheckBit(FlagNoClear) {
 		memclr.ClearUnsafe(unsafe.Pointer(&vec.nodes[0]), vec.nodeL*nodeSize)
 	}
 	vec.nodeL = 0
 
 	vec.buf, vec.src = vec.buf[:0], nil
 	vec.bufKE = vec.bufKE[:0]
+	vec.bufRC = vec.bufRC[:0]
+	vec.bufSP = vec.bufSP[:0]
+	vec.bufSN = vec.bufSN[:0]
+	vec.bufWK = vec.bufWK[:0]
+	vec.lines = vec.lines[:0]
 	vec.addr, vec.nodeL, vec.errOff = 0, 0, 0
 	vec.Index.reset()
+	vec.tb.Reset()
 	vec.Bitset.Reset()
-	vec.SetBit(FlagInit, vec.Helper != nil)
+	vec.setHelperFlags()
+	if vec.adp != nil {
+		vec.adp.presize(vec)
+	}
 }
 
 // ForgetFrom forgets nodes from given position to the end of the array.
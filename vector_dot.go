@@ -1,5 +1,10 @@
 package vector
 
+import (
+	"math/big"
+	"time"
+)
+
 // Shorthands for vector.Get*PS() methods with "." used as separator.
 
 // Dot looks and get node by given path and "." separator.
@@ -46,3 +51,63 @@ func (vec *Vector) DotInt(path string) (int64, error) {
 func (vec *Vector) DotUint(path string) (uint64, error) {
 	return vec.GetUintPS(path, ".")
 }
+
+// DotInt8 looks and get 8-bit integer by given path and "." separator.
+func (vec *Vector) DotInt8(path string) (int8, error) {
+	return vec.GetInt8PS(path, ".")
+}
+
+// DotInt16 looks and get 16-bit integer by given path and "." separator.
+func (vec *Vector) DotInt16(path string) (int16, error) {
+	return vec.GetInt16PS(path, ".")
+}
+
+// DotInt32 looks and get 32-bit integer by given path and "." separator.
+func (vec *Vector) DotInt32(path string) (int32, error) {
+	return vec.GetInt32PS(path, ".")
+}
+
+// DotUint8 looks and get 8-bit unsigned integer by given path and "." separator.
+func (vec *Vector) DotUint8(path string) (uint8, error) {
+	return vec.GetUint8PS(path, ".")
+}
+
+// DotUint16 looks and get 16-bit unsigned integer by given path and "." separator.
+func (vec *Vector) DotUint16(path string) (uint16, error) {
+	return vec.GetUint16PS(path, ".")
+}
+
+// DotUint32 looks and get 32-bit unsigned integer by given path and "." separator.
+func (vec *Vector) DotUint32(path string) (uint32, error) {
+	return vec.GetUint32PS(path, ".")
+}
+
+// DotBigInt looks and get arbitrary-precision integer by given path and "." separator.
+func (vec *Vector) DotBigInt(path string) (*big.Int, error) {
+	return vec.GetBigIntPS(path, ".")
+}
+
+// DotBigFloat looks and get arbitrary-precision float by given path and "." separator.
+func (vec *Vector) DotBigFloat(prec uint, path string) (*big.Float, error) {
+	return vec.GetBigFloatPS(prec, path, ".")
+}
+
+// DotDecimal looks and get lossless decimal by given path and "." separator.
+func (vec *Vector) DotDecimal(path string) (Decimal, error) {
+	return vec.GetDecimalPS(path, ".")
+}
+
+// DotTime looks and get time by given path and "." separator using default layouts (see Vector.SetTimeLayouts).
+func (vec *Vector) DotTime(path string) (time.Time, error) {
+	return vec.GetTimePS(path, ".")
+}
+
+// DotUnix looks and get time from numeric epoch by given path and "." separator.
+func (vec *Vector) DotUnix(path string, unit time.Duration) (time.Time, error) {
+	return vec.GetUnixPS(path, ".", unit)
+}
+
+// DotDuration looks and get duration by given path and "." separator.
+func (vec *Vector) DotDuration(path string) (time.Duration, error) {
+	return vec.GetDurationPS(path, ".")
+}
@@ -1,7 +1,9 @@
 package vector
 
 import (
+	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/koykov/entry"
 )
@@ -103,11 +105,7 @@ func (vec *Vector) GetString(keys ...string) string {
 
 // GetBool looks and get bool by given keys.
 func (vec *Vector) GetBool(keys ...string) bool {
-	node := vec.Get(keys...)
-	if node.Type() != TypeBool {
-		return false
-	}
-	return node.Bool()
+	return vec.Get(keys...).Bool()
 }
 
 // GetFloat looks and get float by given keys.
@@ -146,6 +144,114 @@ func (vec *Vector) GetUint(keys ...string) (uint64, error) {
 	return node.Uint()
 }
 
+// GetInt8 looks and get 8-bit integer by given keys.
+func (vec *Vector) GetInt8(keys ...string) (int8, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Int8()
+}
+
+// GetInt16 looks and get 16-bit integer by given keys.
+func (vec *Vector) GetInt16(keys ...string) (int16, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Int16()
+}
+
+// GetInt32 looks and get 32-bit integer by given keys.
+func (vec *Vector) GetInt32(keys ...string) (int32, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Int32()
+}
+
+// GetUint8 looks and get 8-bit unsigned integer by given keys.
+func (vec *Vector) GetUint8(keys ...string) (uint8, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Uint8()
+}
+
+// GetUint16 looks and get 16-bit unsigned integer by given keys.
+func (vec *Vector) GetUint16(keys ...string) (uint16, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Uint16()
+}
+
+// GetUint32 looks and get 32-bit unsigned integer by given keys.
+func (vec *Vector) GetUint32(keys ...string) (uint32, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Uint32()
+}
+
+// GetBigInt looks and get arbitrary-precision integer by given keys.
+func (vec *Vector) GetBigInt(keys ...string) (*big.Int, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return nil, ErrNotFound
+	}
+	return node.BigInt()
+}
+
+// GetBigFloat looks and get arbitrary-precision float by given keys.
+func (vec *Vector) GetBigFloat(prec uint, keys ...string) (*big.Float, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return nil, ErrNotFound
+	}
+	return node.BigFloat(prec)
+}
+
+// GetDecimal looks and get lossless decimal by given keys.
+func (vec *Vector) GetDecimal(keys ...string) (Decimal, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return Decimal{}, ErrNotFound
+	}
+	return node.Decimal()
+}
+
+// GetTime looks and get time by given keys using default layouts (see Vector.SetTimeLayouts).
+func (vec *Vector) GetTime(keys ...string) (time.Time, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Time()
+}
+
+// GetUnix looks and get time from numeric epoch by given keys.
+func (vec *Vector) GetUnix(unit time.Duration, keys ...string) (time.Time, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Unix(unit)
+}
+
+// GetDuration looks and get duration by given keys.
+func (vec *Vector) GetDuration(keys ...string) (time.Duration, error) {
+	node := vec.Get(keys...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Duration()
+}
+
 // GetPS returns node by given path and separator.
 func (vec *Vector) GetPS(path, separator string) *Node {
 	vec.splitPath(path, separator)
@@ -195,11 +301,7 @@ func (vec *Vector) GetStringPS(path, separator string) string {
 // GetBoolPS looks and get bool by given path and separator.
 func (vec *Vector) GetBoolPS(path, separator string) bool {
 	vec.splitPath(path, separator)
-	node := vec.getKE(path, vec.bufKE...)
-	if node.Type() != TypeBool {
-		return false
-	}
-	return node.Bool()
+	return vec.getKE(path, vec.bufKE...).Bool()
 }
 
 // GetFloatPS looks and get float by given path and separator.
@@ -241,18 +343,139 @@ func (vec *Vector) GetUintPS(path, separator string) (uint64, error) {
 	return node.Uint()
 }
 
+// GetInt8PS looks and get 8-bit integer by given path and separator.
+func (vec *Vector) GetInt8PS(path, separator string) (int8, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Int8()
+}
+
+// GetInt16PS looks and get 16-bit integer by given path and separator.
+func (vec *Vector) GetInt16PS(path, separator string) (int16, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Int16()
+}
+
+// GetInt32PS looks and get 32-bit integer by given path and separator.
+func (vec *Vector) GetInt32PS(path, separator string) (int32, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Int32()
+}
+
+// GetUint8PS looks and get 8-bit unsigned integer by given path and separator.
+func (vec *Vector) GetUint8PS(path, separator string) (uint8, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Uint8()
+}
+
+// GetUint16PS looks and get 16-bit unsigned integer by given path and separator.
+func (vec *Vector) GetUint16PS(path, separator string) (uint16, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Uint16()
+}
+
+// GetUint32PS looks and get 32-bit unsigned integer by given path and separator.
+func (vec *Vector) GetUint32PS(path, separator string) (uint32, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Uint32()
+}
+
+// GetBigIntPS looks and get arbitrary-precision integer by given path and separator.
+func (vec *Vector) GetBigIntPS(path, separator string) (*big.Int, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return nil, ErrNotFound
+	}
+	return node.BigInt()
+}
+
+// GetBigFloatPS looks and get arbitrary-precision float by given path and separator.
+func (vec *Vector) GetBigFloatPS(prec uint, path, separator string) (*big.Float, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return nil, ErrNotFound
+	}
+	return node.BigFloat(prec)
+}
+
+// GetDecimalPS looks and get lossless decimal by given path and separator.
+func (vec *Vector) GetDecimalPS(path, separator string) (Decimal, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return Decimal{}, ErrNotFound
+	}
+	return node.Decimal()
+}
+
+// GetTimePS looks and get time by given path and separator using default layouts (see Vector.SetTimeLayouts).
+func (vec *Vector) GetTimePS(path, separator string) (time.Time, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Time()
+}
+
+// GetUnixPS looks and get time from numeric epoch by given path and separator.
+func (vec *Vector) GetUnixPS(path, separator string, unit time.Duration) (time.Time, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return time.Time{}, ErrNotFound
+	}
+	return node.Unix(unit)
+}
+
+// GetDurationPS looks and get duration by given path and separator.
+func (vec *Vector) GetDurationPS(path, separator string) (time.Duration, error) {
+	vec.splitPath(path, separator)
+	node := vec.getKE(path, vec.bufKE...)
+	if node.Type() == TypeUnknown {
+		return 0, ErrNotFound
+	}
+	return node.Duration()
+}
+
 func (vec *Vector) getArr(root *Node, keys ...string) *Node {
 	if len(keys) == 0 {
 		return root
 	}
+	root.expand()
 	if len(keys) == 1 && root.Type() == TypeArray && root.val.Len() > 0 && root.val.String() == keys[0] {
 		return root
 	}
 	k, err := strconv.Atoi(keys[0])
-	if err != nil || k >= root.limit {
+	if err != nil || k >= int(root.limit) {
 		return nullNode
 	}
-	i := vec.Index.val(root.depth+1, root.offset+k)
+	i := vec.Index.val(int(root.depth)+1, int(root.offset)+k)
 	node := &vec.nodes[i]
 	tail := keys[1:]
 	if node.typ != TypeArray && node.typ != TypeObject {
@@ -274,9 +497,10 @@ func (vec *Vector) getObj(root *Node, keys ...string) *Node {
 	if len(keys) == 0 {
 		return root
 	}
+	root.expand()
 	var node *Node
-	for i := root.offset; i < root.limit; i++ {
-		k := vec.Index.val(root.depth+1, i)
+	for i := int(root.offset); i < int(root.limit); i++ {
+		k := vec.Index.val(int(root.depth)+1, i)
 		if vec.nodes[k].keyEqual(keys[0]) {
 			node = &vec.nodes[k]
 			break
@@ -306,16 +530,17 @@ func (vec *Vector) getArrKE(root *Node, path string, keys ...entry.Entry64) *Nod
 	if len(keys) == 0 {
 		return root
 	}
+	root.expand()
 	lo, hi := keys[0].Decode()
 	skey := path[lo:hi]
 	if len(keys) == 1 && root.Type() == TypeArray && root.val.Len() > 0 && root.val.String() == skey {
 		return root
 	}
 	k, err := strconv.Atoi(skey)
-	if err != nil || k >= root.limit {
+	if err != nil || k >= int(root.limit) {
 		return nullNode
 	}
-	i := vec.Index.val(root.depth+1, root.offset+k)
+	i := vec.Index.val(int(root.depth)+1, int(root.offset)+k)
 	node := &vec.nodes[i]
 	tail := keys[1:]
 	if node.typ != TypeArray && node.typ != TypeObject {
@@ -338,9 +563,10 @@ func (vec *Vector) getObjKE(root *Node, path string, keys ...entry.Entry64) *Nod
 	if len(keys) == 0 {
 		return root
 	}
+	root.expand()
 	var node *Node
-	for i := root.offset; i < root.limit; i++ {
-		k := vec.Index.val(root.depth+1, i)
+	for i := int(root.offset); i < int(root.limit); i++ {
+		k := vec.Index.val(int(root.depth)+1, i)
 		lo, hi := keys[0].Decode()
 		skey := path[lo:hi]
 		if vec.nodes[k].keyEqual(skey) {
@@ -41,7 +41,7 @@ func (vec *Vector) GetChild(root *Node, depth int) (*Node, int) {
 func (vec *Vector) GetChildWT(root *Node, depth int, typ Type) (*Node, int) {
 	node, idx := vec.ackNode(depth)
 	node.typ = typ
-	node.pptr = root.ptr()
+	node.pidx = root.idx
 	root.SetLimit(vec.Index.Register(depth, idx))
 	return node, idx
 }
@@ -0,0 +1,181 @@
+package vector
+
+import "strconv"
+
+// WalkOrder represents order of tree traversal (see Node.Walk).
+type WalkOrder uint8
+
+const (
+	// PreOrder visits node before its children (depth-first).
+	PreOrder WalkOrder = iota
+	// PostOrder visits node after its children (depth-first).
+	PostOrder
+	// BreadthFirst visits nodes level by level.
+	BreadthFirst
+)
+
+// WalkAction controls tree traversal.
+type WalkAction uint8
+
+const (
+	// Continue traversal.
+	Continue WalkAction = iota
+	// SkipChildren skips children of the current node. Makes no sense in post-order traversal.
+	SkipChildren
+	// Stop traversal immediately.
+	Stop
+)
+
+// Traversal frame.
+type walkFrame struct {
+	// Node index, position in parent's children, level relative to the walk root and index of parent frame.
+	idx, pos, level, parent int
+	// Position of the next child to visit (depth-first only).
+	next int
+	// Visited flag (depth-first only).
+	visited bool
+}
+
+// PathCursor represents path of the current node relative to the walk root.
+//
+// Cursor is valid only inside walk callback and mustn't be stored.
+type PathCursor struct {
+	vec *Vector
+	cur int
+}
+
+// Len returns count of path items (depth of the current node relative to the walk root).
+func (c PathCursor) Len() int {
+	if c.vec == nil {
+		return 0
+	}
+	return c.vec.bufWK[c.cur].level
+}
+
+// Key returns key of the i-th path item. Items of arrays have no keys.
+func (c PathCursor) Key(i int) []byte {
+	if f := c.frame(i); f != nil {
+		return c.vec.nodes[f.idx].key.Bytes()
+	}
+	return nil
+}
+
+// Index returns position of the i-th path item in its parent.
+func (c PathCursor) Index(i int) int {
+	if f := c.frame(i); f != nil {
+		return f.pos
+	}
+	return -1
+}
+
+// AppendPath appends path of the current node with given separator to dst.
+//
+// Path consists of keys of object children and indexes of array children.
+func (c PathCursor) AppendPath(dst []byte, separator string) []byte {
+	n := c.Len()
+	for i := 1; i <= n; i++ {
+		if i > 1 {
+			dst = append(dst, separator...)
+		}
+		f := c.frame(i)
+		if c.vec.nodes[c.vec.bufWK[f.parent].idx].typ == TypeArray {
+			dst = strconv.AppendInt(dst, int64(f.pos), 10)
+		} else {
+			dst = append(dst, c.vec.nodes[f.idx].key.Bytes()...)
+		}
+	}
+	return dst
+}
+
+// Get frame of the i-th path item (starting from 1).
+func (c PathCursor) frame(i int) *walkFrame {
+	if c.vec == nil || i < 1 {
+		return nil
+	}
+	f := &c.vec.bufWK[c.cur]
+	if i > f.level {
+		return nil
+	}
+	for f.level > i {
+		f = &c.vec.bufWK[f.parent]
+	}
+	return f
+}
+
+// Walk traverses the node and all its descendants in given order and applies closure to each of them.
+//
+// Closure takes cursor of the path relative to the node (empty for the node itself) and controls further traversal
+// using returning action. Traversal state stores in the vector's buffer, thus walk doesn't allocate in steady state.
+// Children of aliases aren't visited to avoid cycles.
+func (n *Node) Walk(order WalkOrder, fn func(path PathCursor, node *Node) WalkAction) {
+	vec := n.indirectVector()
+	if vec == nil {
+		return
+	}
+	// Nested walks use the rest of the buffer.
+	base := len(vec.bufWK)
+	vec.bufWK = append(vec.bufWK, walkFrame{idx: int(n.idx), parent: -1})
+	if order == BreadthFirst {
+		vec.walkBF(base, fn)
+	} else {
+		vec.walkDF(base, order, fn)
+	}
+	vec.bufWK = vec.bufWK[:base]
+}
+
+// Breadth-first traversal.
+func (vec *Vector) walkBF(base int, fn func(path PathCursor, node *Node) WalkAction) {
+	for head := base; head < len(vec.bufWK); head++ {
+		f := vec.bufWK[head]
+		switch fn(PathCursor{vec: vec, cur: head}, &vec.nodes[f.idx]) {
+		case Stop:
+			return
+		case SkipChildren:
+			continue
+		}
+		ci := vec.nodes[f.idx].walkChildren()
+		for i := 0; i < len(ci); i++ {
+			vec.bufWK = append(vec.bufWK, walkFrame{idx: ci[i], pos: i, level: f.level + 1, parent: head})
+		}
+	}
+}
+
+// Depth-first traversal.
+func (vec *Vector) walkDF(base int, order WalkOrder, fn func(path PathCursor, node *Node) WalkAction) {
+	for top := base; top >= base; top = len(vec.bufWK) - 1 {
+		f := &vec.bufWK[top]
+		if !f.visited {
+			f.visited = true
+			if order == PreOrder {
+				switch fn(PathCursor{vec: vec, cur: top}, &vec.nodes[f.idx]) {
+				case Stop:
+					return
+				case SkipChildren:
+					vec.bufWK = vec.bufWK[:top]
+					continue
+				}
+				// Buffer may grow in the closure.
+				f = &vec.bufWK[top]
+			}
+		}
+		ci := vec.nodes[f.idx].walkChildren()
+		if f.next < len(ci) {
+			c := walkFrame{idx: ci[f.next], pos: f.next, level: f.level + 1, parent: top}
+			f.next++
+			vec.bufWK = append(vec.bufWK, c)
+			continue
+		}
+		if order == PostOrder && fn(PathCursor{vec: vec, cur: top}, &vec.nodes[f.idx]) == Stop {
+			return
+		}
+		vec.bufWK = vec.bufWK[:top]
+	}
+}
+
+// Get children indices to walk.
+func (n *Node) walkChildren() []int {
+	if n.typ != TypeObject && n.typ != TypeArray {
+		return nil
+	}
+	return n.childrenIdx()
+}
@@ -0,0 +1,56 @@
+package vector
+
+import "testing"
+
+func TestWalk(t *testing.T) {
+	const src = `{"a":{"b":"foo"},"c":[1,2]}`
+	vec := testPool.Get().(*Vector)
+	defer testPool.Put(vec)
+	defer vec.Reset()
+	vec.Reset()
+	_ = vec.SetSrc([]byte(src), false)
+	testEmit(vec)
+
+	walk := func(order WalkOrder, skip string) string {
+		var buf []byte
+		vec.Root().Walk(order, func(path PathCursor, _ *Node) WalkAction {
+			buf = append(buf, '/')
+			buf = path.AppendPath(buf, ".")
+			if path.Len() > 0 && string(path.Key(path.Len())) == skip {
+				return SkipChildren
+			}
+			return Continue
+		})
+		return string(buf)
+	}
+	stages := []struct {
+		order WalkOrder
+		skip  string
+		need  string
+	}{
+		{PreOrder, "", "//a/a.b/c/c.0/c.1"},
+		{PreOrder, "c", "//a/a.b/c"},
+		{PostOrder, "", "/a.b/a/c.0/c.1/c/"},
+		{BreadthFirst, "", "//a/c/a.b/c.0/c.1"},
+		{BreadthFirst, "a", "//a/c/c.0/c.1"},
+	}
+	for _, st := range stages {
+		if r := walk(st.order, st.skip); r != st.need {
+			t.Errorf("walk %d mismatch: need '%s', got '%s'", st.order, st.need, r)
+		}
+	}
+
+	var c int
+	vec.Root().Walk(PreOrder, func(path PathCursor, _ *Node) WalkAction {
+		if c++; path.Index(path.Len()) == 1 {
+			return Stop
+		}
+		return Continue
+	})
+	if c != 4 {
+		t.Errorf("stop failed: need 4 visits, got %d", c)
+	}
+	if len(vec.bufWK) != 0 {
+		t.Error("walk buffer must be released")
+	}
+}